@@ -20,6 +20,8 @@ package apis
 import (
 	"k8s.io/apimachinery/pkg/runtime"
 
+	s3v1alpha1 "github.com/statnett/provider-cloudian/apis/s3/v1alpha1"
+	storagev1alpha1 "github.com/statnett/provider-cloudian/apis/storage/v1alpha1"
 	userv1alpha1 "github.com/statnett/provider-cloudian/apis/user/v1alpha1"
 	cloudianv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
 )
@@ -29,6 +31,8 @@ func init() {
 	AddToSchemes = append(AddToSchemes,
 		cloudianv1alpha1.SchemeBuilder.AddToScheme,
 		userv1alpha1.SchemeBuilder.AddToScheme,
+		s3v1alpha1.SchemeBuilder.AddToScheme,
+		storagev1alpha1.SchemeBuilder.AddToScheme,
 	)
 }
 
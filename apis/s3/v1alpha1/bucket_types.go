@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// BucketParameters are the configurable fields of a Bucket.
+// +kubebuilder:validation:XValidation:rule="has(self.accessKeyId) || has(self.accessKeyIdRef) || has(self.accessKeyIdSelector) || has(self.userId) || has(self.userIdRef) || has(self.userIdSelector)",message="an access key or a user must own the bucket"
+type BucketParameters struct {
+	// S3Credentials select the access key that creates, and so owns, the bucket.
+	S3Credentials `json:",inline"`
+
+	// Region of the bucket. The region of the ProviderConfig if unspecified.
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="region is immutable"
+	Region string `json:"region,omitempty"`
+
+	// StoragePolicyID is the ID of the Cloudian storage policy of the bucket.
+	// The default storage policy if unspecified.
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="storagePolicyId is immutable"
+	StoragePolicyID string `json:"storagePolicyId,omitempty"`
+
+	// StoragePolicyIDRef references a storage policy to retrieve its ID.
+	// +optional
+	// +immutable
+	StoragePolicyIDRef *xpv1.Reference `json:"storagePolicyIdRef,omitempty"`
+
+	// StoragePolicyIDSelector selects a storage policy to retrieve its ID.
+	// +optional
+	StoragePolicyIDSelector *xpv1.Selector `json:"storagePolicyIdSelector,omitempty"`
+
+	// ObjectLockEnabled enables S3 Object Lock for the bucket.
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="objectLockEnabled is immutable"
+	ObjectLockEnabled bool `json:"objectLockEnabled,omitempty"`
+}
+
+// BucketObservation are the observable fields of a Bucket.
+type BucketObservation struct {
+	Region            string `json:"region,omitempty"`
+	ObjectLockEnabled bool   `json:"objectLockEnabled,omitempty"`
+}
+
+// A BucketSpec defines the desired state of a Bucket.
+type BucketSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       BucketParameters `json:"forProvider"`
+}
+
+// A BucketStatus represents the observed state of a Bucket.
+type BucketStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          BucketObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// Bucket represents an S3 bucket in Cloudian.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudian}
+type Bucket struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BucketSpec   `json:"spec"`
+	Status BucketStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BucketList contains a list of Bucket
+type BucketList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Bucket `json:"items"`
+}
+
+// Bucket type metadata.
+var (
+	BucketKind             = reflect.TypeOf(Bucket{}).Name()
+	BucketGroupKind        = schema.GroupKind{Group: MetadataGroup, Kind: BucketKind}.String()
+	BucketKindAPIVersion   = BucketKind + "." + SchemeGroupVersion.String()
+	BucketGroupVersionKind = SchemeGroupVersion.WithKind(BucketKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Bucket{}, &BucketList{})
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// BucketCORSConfigurationParameters are the configurable fields of a BucketCORSConfiguration.
+// +kubebuilder:validation:XValidation:rule="has(self.bucket) || has(self.bucketRef) || has(self.bucketSelector)",message="a bucket must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.accessKeyId) || has(self.accessKeyIdRef) || has(self.accessKeyIdSelector) || has(self.userId) || has(self.userIdRef) || has(self.userIdSelector)",message="an access key or a user must be set"
+type BucketCORSConfigurationParameters struct {
+	BucketTarget `json:",inline"`
+
+	// S3Credentials select the access key that configures the bucket.
+	S3Credentials `json:",inline"`
+
+	// Rules allow cross-origin requests to the bucket.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	Rules []CORSRule `json:"rules"`
+}
+
+// CORSRule allows cross-origin requests to a bucket.
+type CORSRule struct {
+	// ID identifies the rule.
+	// +optional
+	// +kubebuilder:validation:MaxLength=255
+	ID string `json:"id,omitempty"`
+
+	// AllowedMethods are the HTTP methods origins may use.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Enum=GET;PUT;POST;DELETE;HEAD
+	AllowedMethods []string `json:"allowedMethods"`
+
+	// AllowedOrigins are the origins allowed to make requests, which may
+	// contain a single * wildcard.
+	// +kubebuilder:validation:MinItems=1
+	AllowedOrigins []string `json:"allowedOrigins"`
+
+	// AllowedHeaders are the headers allowed in preflight requests.
+	// +optional
+	AllowedHeaders []string `json:"allowedHeaders,omitempty"`
+
+	// ExposeHeaders are the response headers clients may access.
+	// +optional
+	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
+
+	// MaxAgeSeconds is how long browsers may cache a preflight response.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxAgeSeconds *int32 `json:"maxAgeSeconds,omitempty"`
+}
+
+// BucketCORSConfigurationObservation are the observable fields of a BucketCORSConfiguration.
+type BucketCORSConfigurationObservation struct {
+	// Rules are the CORS rules of the bucket.
+	Rules []CORSRule `json:"rules,omitempty"`
+}
+
+// A BucketCORSConfigurationSpec defines the desired state of a BucketCORSConfiguration.
+type BucketCORSConfigurationSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       BucketCORSConfigurationParameters `json:"forProvider"`
+}
+
+// A BucketCORSConfigurationStatus represents the observed state of a BucketCORSConfiguration.
+type BucketCORSConfigurationStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          BucketCORSConfigurationObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BucketCORSConfiguration represents the CORS rules of an S3 bucket in Cloudian.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="BUCKET",type="string",JSONPath=".spec.forProvider.bucket"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudian}
+type BucketCORSConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BucketCORSConfigurationSpec   `json:"spec"`
+	Status BucketCORSConfigurationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BucketCORSConfigurationList contains a list of BucketCORSConfiguration
+type BucketCORSConfigurationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BucketCORSConfiguration `json:"items"`
+}
+
+// BucketCORSConfiguration type metadata.
+var (
+	BucketCORSConfigurationKind             = reflect.TypeOf(BucketCORSConfiguration{}).Name()
+	BucketCORSConfigurationGroupKind        = schema.GroupKind{Group: MetadataGroup, Kind: BucketCORSConfigurationKind}.String()
+	BucketCORSConfigurationKindAPIVersion   = BucketCORSConfigurationKind + "." + SchemeGroupVersion.String()
+	BucketCORSConfigurationGroupVersionKind = SchemeGroupVersion.WithKind(BucketCORSConfigurationKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&BucketCORSConfiguration{}, &BucketCORSConfigurationList{})
+}
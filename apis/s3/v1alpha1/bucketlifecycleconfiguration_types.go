@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// BucketLifecycleConfigurationParameters are the configurable fields of a BucketLifecycleConfiguration.
+// +kubebuilder:validation:XValidation:rule="has(self.bucket) || has(self.bucketRef) || has(self.bucketSelector)",message="a bucket must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.accessKeyId) || has(self.accessKeyIdRef) || has(self.accessKeyIdSelector) || has(self.userId) || has(self.userIdRef) || has(self.userIdSelector)",message="an access key or a user must be set"
+type BucketLifecycleConfigurationParameters struct {
+	BucketTarget `json:",inline"`
+
+	// S3Credentials select the access key that configures the bucket.
+	S3Credentials `json:",inline"`
+
+	// Rules expire objects of the bucket.
+	// +listType=map
+	// +listMapKey=id
+	// +kubebuilder:validation:MinItems=1
+	Rules []LifecycleRule `json:"rules"`
+}
+
+// LifecycleRule expires objects of a bucket.
+type LifecycleRule struct {
+	// ID identifies the rule.
+	// +kubebuilder:validation:MaxLength=255
+	ID string `json:"id"`
+
+	// Status enables or disables the rule.
+	// +optional
+	// +kubebuilder:default=Enabled
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	Status string `json:"status,omitempty"`
+
+	// Prefix limits the rule to objects with keys starting with it.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// ExpirationDays expires current object versions after a number of days.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ExpirationDays *int32 `json:"expirationDays,omitempty"`
+
+	// NoncurrentVersionExpirationDays deletes object versions a number of
+	// days after they become noncurrent.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	NoncurrentVersionExpirationDays *int32 `json:"noncurrentVersionExpirationDays,omitempty"`
+
+	// AbortIncompleteMultipartUploadDays aborts multipart uploads that are
+	// not completed a number of days after they are initiated.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	AbortIncompleteMultipartUploadDays *int32 `json:"abortIncompleteMultipartUploadDays,omitempty"`
+}
+
+// BucketLifecycleConfigurationObservation are the observable fields of a BucketLifecycleConfiguration.
+type BucketLifecycleConfigurationObservation struct {
+	// Rules are the lifecycle rules of the bucket.
+	Rules []LifecycleRule `json:"rules,omitempty"`
+}
+
+// A BucketLifecycleConfigurationSpec defines the desired state of a BucketLifecycleConfiguration.
+type BucketLifecycleConfigurationSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       BucketLifecycleConfigurationParameters `json:"forProvider"`
+}
+
+// A BucketLifecycleConfigurationStatus represents the observed state of a BucketLifecycleConfiguration.
+type BucketLifecycleConfigurationStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          BucketLifecycleConfigurationObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BucketLifecycleConfiguration represents the lifecycle rules of an S3 bucket in Cloudian.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="BUCKET",type="string",JSONPath=".spec.forProvider.bucket"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudian}
+type BucketLifecycleConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BucketLifecycleConfigurationSpec   `json:"spec"`
+	Status BucketLifecycleConfigurationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BucketLifecycleConfigurationList contains a list of BucketLifecycleConfiguration
+type BucketLifecycleConfigurationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BucketLifecycleConfiguration `json:"items"`
+}
+
+// BucketLifecycleConfiguration type metadata.
+var (
+	BucketLifecycleConfigurationKind             = reflect.TypeOf(BucketLifecycleConfiguration{}).Name()
+	BucketLifecycleConfigurationGroupKind        = schema.GroupKind{Group: MetadataGroup, Kind: BucketLifecycleConfigurationKind}.String()
+	BucketLifecycleConfigurationKindAPIVersion   = BucketLifecycleConfigurationKind + "." + SchemeGroupVersion.String()
+	BucketLifecycleConfigurationGroupVersionKind = SchemeGroupVersion.WithKind(BucketLifecycleConfigurationKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&BucketLifecycleConfiguration{}, &BucketLifecycleConfigurationList{})
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// BucketPolicyParameters are the configurable fields of a BucketPolicy.
+// +kubebuilder:validation:XValidation:rule="has(self.bucket) || has(self.bucketRef) || has(self.bucketSelector)",message="a bucket must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.accessKeyId) || has(self.accessKeyIdRef) || has(self.accessKeyIdSelector) || has(self.userId) || has(self.userIdRef) || has(self.userIdSelector)",message="an access key or a user must be set"
+type BucketPolicyParameters struct {
+	BucketTarget `json:",inline"`
+
+	// S3Credentials select the access key that configures the bucket.
+	S3Credentials `json:",inline"`
+
+	// Policy is the JSON bucket policy document. It is compared semantically
+	// to the policy of the bucket, so formatting and key order are ignored.
+	Policy string `json:"policy"`
+}
+
+// BucketPolicyObservation are the observable fields of a BucketPolicy.
+type BucketPolicyObservation struct {
+	// Policy is the JSON policy document of the bucket.
+	Policy string `json:"policy,omitempty"`
+}
+
+// A BucketPolicySpec defines the desired state of a BucketPolicy.
+type BucketPolicySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       BucketPolicyParameters `json:"forProvider"`
+}
+
+// A BucketPolicyStatus represents the observed state of a BucketPolicy.
+type BucketPolicyStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          BucketPolicyObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BucketPolicy represents a policy of an S3 bucket in Cloudian.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="BUCKET",type="string",JSONPath=".spec.forProvider.bucket"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudian}
+type BucketPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BucketPolicySpec   `json:"spec"`
+	Status BucketPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BucketPolicyList contains a list of BucketPolicy
+type BucketPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BucketPolicy `json:"items"`
+}
+
+// BucketPolicy type metadata.
+var (
+	BucketPolicyKind             = reflect.TypeOf(BucketPolicy{}).Name()
+	BucketPolicyGroupKind        = schema.GroupKind{Group: MetadataGroup, Kind: BucketPolicyKind}.String()
+	BucketPolicyKindAPIVersion   = BucketPolicyKind + "." + SchemeGroupVersion.String()
+	BucketPolicyGroupVersionKind = SchemeGroupVersion.WithKind(BucketPolicyKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&BucketPolicy{}, &BucketPolicyList{})
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// BucketVersioningParameters are the configurable fields of a BucketVersioning.
+// +kubebuilder:validation:XValidation:rule="has(self.bucket) || has(self.bucketRef) || has(self.bucketSelector)",message="a bucket must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.accessKeyId) || has(self.accessKeyIdRef) || has(self.accessKeyIdSelector) || has(self.userId) || has(self.userIdRef) || has(self.userIdSelector)",message="an access key or a user must be set"
+type BucketVersioningParameters struct {
+	BucketTarget `json:",inline"`
+
+	// S3Credentials select the access key that configures the bucket.
+	S3Credentials `json:",inline"`
+
+	// Status is the versioning state of the bucket. Versioning cannot be
+	// disabled once enabled, only suspended, and it is suspended when the
+	// BucketVersioning is deleted.
+	// +kubebuilder:validation:Enum=Enabled;Suspended
+	Status string `json:"status"`
+}
+
+// BucketVersioningObservation are the observable fields of a BucketVersioning.
+type BucketVersioningObservation struct {
+	// Status is the versioning state of the bucket, which is empty if
+	// versioning has never been enabled.
+	Status string `json:"status,omitempty"`
+}
+
+// A BucketVersioningSpec defines the desired state of a BucketVersioning.
+type BucketVersioningSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       BucketVersioningParameters `json:"forProvider"`
+}
+
+// A BucketVersioningStatus represents the observed state of a BucketVersioning.
+type BucketVersioningStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          BucketVersioningObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BucketVersioning represents the versioning state of an S3 bucket in Cloudian.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="BUCKET",type="string",JSONPath=".spec.forProvider.bucket"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudian}
+type BucketVersioning struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BucketVersioningSpec   `json:"spec"`
+	Status BucketVersioningStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BucketVersioningList contains a list of BucketVersioning
+type BucketVersioningList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BucketVersioning `json:"items"`
+}
+
+// BucketVersioning type metadata.
+var (
+	BucketVersioningKind             = reflect.TypeOf(BucketVersioning{}).Name()
+	BucketVersioningGroupKind        = schema.GroupKind{Group: MetadataGroup, Kind: BucketVersioningKind}.String()
+	BucketVersioningKindAPIVersion   = BucketVersioningKind + "." + SchemeGroupVersion.String()
+	BucketVersioningGroupVersionKind = SchemeGroupVersion.WithKind(BucketVersioningKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&BucketVersioning{}, &BucketVersioningList{})
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// S3Credentials selects the access key used to send S3 requests. Either an
+// access key, or a user with an active access key, must be set.
+type S3Credentials struct {
+	// AccessKeyID of the access key.
+	// +optional
+	AccessKeyID string `json:"accessKeyId,omitempty"`
+
+	// AccessKeyIDRef references an access key to retrieve its accessKeyId.
+	// +optional
+	AccessKeyIDRef *xpv1.Reference `json:"accessKeyIdRef,omitempty"`
+
+	// AccessKeyIDSelector selects an access key to retrieve its accessKeyId.
+	// +optional
+	AccessKeyIDSelector *xpv1.Selector `json:"accessKeyIdSelector,omitempty"`
+
+	// GroupID of the user.
+	// +optional
+	GroupID string `json:"groupId,omitempty"`
+
+	// UserID of the user.
+	// +optional
+	UserID string `json:"userId,omitempty"`
+
+	// UserIDRef references a user to retrieve its groupId and userId.
+	// +optional
+	UserIDRef *xpv1.Reference `json:"userIdRef,omitempty"`
+
+	// UserIDSelector selects a user to retrieve its groupId and userId.
+	// +optional
+	UserIDSelector *xpv1.Selector `json:"userIdSelector,omitempty"`
+}
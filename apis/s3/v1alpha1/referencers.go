@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	resource "github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	storagev1alpha1 "github.com/statnett/provider-cloudian/apis/storage/v1alpha1"
+	userv1alpha1 "github.com/statnett/provider-cloudian/apis/user/v1alpha1"
+)
+
+// ResolveReferences of this Bucket
+func (mg *Bucket) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.StoragePolicyID,
+		Reference:    mg.Spec.ForProvider.StoragePolicyIDRef,
+		Selector:     mg.Spec.ForProvider.StoragePolicyIDSelector,
+		To:           reference.To{Managed: &storagev1alpha1.StoragePolicy{}, List: &storagev1alpha1.StoragePolicyList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to resolve spec.forProvider.storagePolicyId")
+	}
+
+	mg.Spec.ForProvider.StoragePolicyID = rsp.ResolvedValue
+	mg.Spec.ForProvider.StoragePolicyIDRef = rsp.ResolvedReference
+
+	return mg.Spec.ForProvider.S3Credentials.resolveReferences(ctx, r)
+}
+
+// ResolveReferences of this BucketPolicy
+func (mg *BucketPolicy) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+	if err := mg.Spec.ForProvider.BucketTarget.resolveReferences(ctx, r); err != nil {
+		return err
+	}
+	return mg.Spec.ForProvider.S3Credentials.resolveReferences(ctx, r)
+}
+
+// ResolveReferences of this BucketVersioning
+func (mg *BucketVersioning) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+	if err := mg.Spec.ForProvider.BucketTarget.resolveReferences(ctx, r); err != nil {
+		return err
+	}
+	return mg.Spec.ForProvider.S3Credentials.resolveReferences(ctx, r)
+}
+
+// ResolveReferences of this BucketLifecycleConfiguration
+func (mg *BucketLifecycleConfiguration) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+	if err := mg.Spec.ForProvider.BucketTarget.resolveReferences(ctx, r); err != nil {
+		return err
+	}
+	return mg.Spec.ForProvider.S3Credentials.resolveReferences(ctx, r)
+}
+
+// ResolveReferences of this BucketCORSConfiguration
+func (mg *BucketCORSConfiguration) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+	if err := mg.Spec.ForProvider.BucketTarget.resolveReferences(ctx, r); err != nil {
+		return err
+	}
+	return mg.Spec.ForProvider.S3Credentials.resolveReferences(ctx, r)
+}
+
+// resolveReferences resolves the name of a bucket.
+func (bt *BucketTarget) resolveReferences(ctx context.Context, r *reference.APIResolver) error {
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: bt.Bucket,
+		Reference:    bt.BucketRef,
+		Selector:     bt.BucketSelector,
+		To:           reference.To{Managed: &Bucket{}, List: &BucketList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to resolve spec.forProvider.bucket")
+	}
+
+	bt.Bucket = rsp.ResolvedValue
+	bt.BucketRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// resolveReferences resolves the access key or user of S3 credentials.
+func (cr *S3Credentials) resolveReferences(ctx context.Context, r *reference.APIResolver) error {
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: cr.AccessKeyID,
+		Reference:    cr.AccessKeyIDRef,
+		Selector:     cr.AccessKeyIDSelector,
+		To:           reference.To{Managed: &userv1alpha1.AccessKey{}, List: &userv1alpha1.AccessKeyList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to resolve spec.forProvider.accessKeyId")
+	}
+
+	cr.AccessKeyID = rsp.ResolvedValue
+	cr.AccessKeyIDRef = rsp.ResolvedReference
+
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: cr.UserID,
+		Reference:    cr.UserIDRef,
+		Selector:     cr.UserIDSelector,
+		To:           reference.To{Managed: &userv1alpha1.User{}, List: &userv1alpha1.UserList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to resolve spec.forProvider.userId")
+	}
+
+	cr.UserID = rsp.ResolvedValue
+	cr.UserIDRef = rsp.ResolvedReference
+
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: cr.GroupID,
+		Reference:    cr.UserIDRef,
+		Selector:     cr.UserIDSelector,
+		To:           reference.To{Managed: &userv1alpha1.User{}, List: &userv1alpha1.UserList{}},
+		Extract: func(mg resource.Managed) string {
+			user, ok := mg.(*userv1alpha1.User)
+			if !ok {
+				return ""
+			}
+			return user.Spec.ForProvider.GroupID
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to resolve spec.forProvider.groupId")
+	}
+
+	cr.GroupID = rsp.ResolvedValue
+
+	return nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// StoragePolicyParameters are the configurable fields of a StoragePolicy.
+// +kubebuilder:validation:XValidation:rule="self.scheme != 'REPLICATION' || has(self.replicas)",message="replicas must be set for the REPLICATION scheme"
+// +kubebuilder:validation:XValidation:rule="self.scheme != 'EC' || has(self.erasureCoding)",message="erasureCoding must be set for the EC scheme"
+type StoragePolicyParameters struct {
+	// PolicyName is the display name of the storage policy.
+	PolicyName string `json:"policyName"`
+
+	// Description of the storage policy.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Region of the storage policy. The default region if unspecified.
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="region is immutable"
+	Region string `json:"region,omitempty"`
+
+	// Scheme protects data by replicating objects (REPLICATION), or by
+	// splitting objects into data and parity fragments (EC).
+	// +immutable
+	// +kubebuilder:validation:Enum=REPLICATION;EC
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="scheme is immutable"
+	Scheme string `json:"scheme"`
+
+	// Replicas is the number of replicas in each data center of the
+	// REPLICATION scheme.
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="replicas is immutable"
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// ErasureCoding configures the fragments of the EC scheme.
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="erasureCoding is immutable"
+	ErasureCoding *ErasureCoding `json:"erasureCoding,omitempty"`
+
+	// DataCenters store the data of the buckets bound to the policy.
+	// +immutable
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="dataCenters is immutable"
+	DataCenters []string `json:"dataCenters"`
+
+	// Compression of objects at rest.
+	// +optional
+	// +kubebuilder:default=NONE
+	// +kubebuilder:validation:Enum=NONE;SNAPPY;ZLIB;LZ4
+	Compression string `json:"compression,omitempty"`
+
+	// Encryption of objects at rest.
+	// +optional
+	// +kubebuilder:default=NONE
+	// +kubebuilder:validation:Enum=NONE;SSE
+	Encryption string `json:"encryption,omitempty"`
+
+	// ReadConsistency is the consistency level of reads.
+	// +optional
+	// +kubebuilder:default=QUORUM
+	// +kubebuilder:validation:Enum=ONE;QUORUM;LOCAL_QUORUM;EACH_QUORUM;ALL
+	ReadConsistency string `json:"readConsistency,omitempty"`
+
+	// WriteConsistency is the consistency level of writes.
+	// +optional
+	// +kubebuilder:default=QUORUM
+	// +kubebuilder:validation:Enum=ONE;QUORUM;LOCAL_QUORUM;EACH_QUORUM;ALL
+	WriteConsistency string `json:"writeConsistency,omitempty"`
+
+	// Groups may bind buckets to the policy. All groups may if unspecified.
+	// +optional
+	// +listType=set
+	Groups []string `json:"groups,omitempty"`
+}
+
+// ErasureCoding splits each object into data fragments and parity fragments,
+// so that it can be recovered from any DataFragments of them.
+type ErasureCoding struct {
+	// DataFragments (k) is the number of data fragments of an object.
+	// +kubebuilder:validation:Minimum=1
+	DataFragments int32 `json:"dataFragments"`
+
+	// ParityFragments (m) is the number of parity fragments of an object.
+	// +kubebuilder:validation:Minimum=1
+	ParityFragments int32 `json:"parityFragments"`
+}
+
+// StoragePolicyObservation are the observable fields of a StoragePolicy.
+type StoragePolicyObservation struct {
+	// PolicyID is the ID Cloudian assigned to the storage policy, which
+	// buckets are bound to it by.
+	PolicyID string `json:"policyId,omitempty"`
+
+	// Status is ACTIVE, PENDING or DISABLED.
+	Status string `json:"status,omitempty"`
+
+	// Default is whether buckets are bound to the policy unless they specify
+	// another one.
+	Default bool `json:"default,omitempty"`
+}
+
+// A StoragePolicySpec defines the desired state of a StoragePolicy.
+type StoragePolicySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       StoragePolicyParameters `json:"forProvider"`
+}
+
+// A StoragePolicyStatus represents the observed state of a StoragePolicy.
+type StoragePolicyStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          StoragePolicyObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// StoragePolicy represents a Cloudian storage policy, also known as a bucket
+// protection policy. Its external name is the policy ID assigned by Cloudian.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudian}
+type StoragePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StoragePolicySpec   `json:"spec"`
+	Status StoragePolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// StoragePolicyList contains a list of StoragePolicy
+type StoragePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StoragePolicy `json:"items"`
+}
+
+// StoragePolicy type metadata.
+var (
+	StoragePolicyKind             = reflect.TypeOf(StoragePolicy{}).Name()
+	StoragePolicyGroupKind        = schema.GroupKind{Group: MetadataGroup, Kind: StoragePolicyKind}.String()
+	StoragePolicyKindAPIVersion   = StoragePolicyKind + "." + SchemeGroupVersion.String()
+	StoragePolicyGroupVersionKind = SchemeGroupVersion.WithKind(StoragePolicyKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&StoragePolicy{}, &StoragePolicyList{})
+}
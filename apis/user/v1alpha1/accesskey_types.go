@@ -45,12 +45,99 @@ type AccessKeyParameters struct {
 	// UserIDSelector selects a user to retrieve its groupId and userId.
 	// +optional
 	UserIDSelector *xpv1.Selector `json:"userIdSelector,omitempty"`
+
+	// Active determines whether the access key can be used (true), or is kept
+	// but rejected by Cloudian (false).
+	// +optional
+	// +kubebuilder:default=true
+	Active bool `json:"active"`
+
+	// Rotation replaces the access key with a new one when it gets too old.
+	// +optional
+	Rotation *AccessKeyRotation `json:"rotation,omitempty"`
+
+	// ConnectionFormats are additional formats the access key is published in
+	// to the connection secret. The access key, the secret key and the S3
+	// endpoint of the ProviderConfig are always published.
+	// +optional
+	// +listType=set
+	ConnectionFormats []ConnectionFormat `json:"connectionFormats,omitempty"`
+
+	// Profile is the name of the profile in an AWS credentials file, and of
+	// the remote in an rclone configuration file.
+	// +optional
+	// +kubebuilder:default=default
+	Profile string `json:"profile,omitempty"`
+
+	// ConnectionTemplates are Go text/templates rendered to the connection
+	// secret, keyed by the connection secret key they are published to.
+	// Templates can refer to .AccessKey, .SecretKey, .UserID, .GroupID,
+	// .CanonicalID, .Endpoint, .S3Endpoint and .Region, and quote values
+	// with the json function.
+	// +optional
+	ConnectionTemplates map[string]string `json:"connectionTemplates,omitempty"`
+}
+
+// A ConnectionFormat is a format of S3 client configuration published to the
+// connection secret of an access key.
+// +kubebuilder:validation:Enum=Env;AWSCredentials;S3cmd;Rclone
+type ConnectionFormat string
+
+// Connection formats.
+const (
+	// ConnectionFormatEnv publishes AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY,
+	// AWS_ENDPOINT_URL and AWS_REGION keys, for use as environment variables.
+	ConnectionFormatEnv ConnectionFormat = "Env"
+	// ConnectionFormatAWSCredentials publishes an AWS credentials file in the
+	// credentials key.
+	ConnectionFormatAWSCredentials ConnectionFormat = "AWSCredentials"
+	// ConnectionFormatS3cmd publishes an s3cmd configuration file in the
+	// .s3cfg key.
+	ConnectionFormatS3cmd ConnectionFormat = "S3cmd"
+	// ConnectionFormatRclone publishes an rclone configuration file in the
+	// rclone.conf key.
+	ConnectionFormatRclone ConnectionFormat = "Rclone"
+)
+
+// AccessKeyRotation configures automatic rotation of an access key. When the
+// access key reaches its max age, a new access key is created and published.
+// The previous access key is published alongside the new one during the
+// overlap period, and is deleted when the grace period after it has passed.
+// +kubebuilder:validation:XValidation:rule="duration(self.overlapPeriod) + duration(self.gracePeriod) < duration(self.maxAge)",message="overlapPeriod and gracePeriod must together be shorter than maxAge"
+type AccessKeyRotation struct {
+	// MaxAge is how long an access key is used before it is rotated.
+	MaxAge metav1.Duration `json:"maxAge"`
+
+	// OverlapPeriod is how long both the previous and the new access key are
+	// published after a rotation.
+	// +optional
+	// +kubebuilder:default="24h"
+	OverlapPeriod metav1.Duration `json:"overlapPeriod"`
+
+	// GracePeriod is how long the previous access key stays valid after the
+	// overlap period, before it is deleted.
+	// +optional
+	// +kubebuilder:default="0s"
+	GracePeriod metav1.Duration `json:"gracePeriod"`
 }
 
 // AccessKeyObservation are the observable fields of a AccessKey.
 type AccessKeyObservation struct {
 	// AccessKey is the S3 Access Key ID, with a corresponding SecretKey.
 	AccessKey string `json:"accessKey,omitempty"`
+
+	// Active is whether Cloudian accepts the access key.
+	Active bool `json:"active,omitempty"`
+
+	// CreatedAt is when the access key was created.
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+
+	// PreviousAccessKey is the access key replaced by the last rotation. It
+	// is deleted when the grace period of the rotation has passed.
+	PreviousAccessKey string `json:"previousAccessKey,omitempty"`
+
+	// PreviousCreatedAt is when the previous access key was created.
+	PreviousCreatedAt *metav1.Time `json:"previousCreatedAt,omitempty"`
 }
 
 // A AccessKeySpec defines the desired state of a AccessKey.
@@ -0,0 +1,42 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Condition types and reasons of the connection templates of an AccessKey
+// or User.
+const (
+	// TypeConnectionTemplatesRendered indicates whether the connection
+	// templates of an AccessKey or User could be rendered.
+	TypeConnectionTemplatesRendered xpv1.ConditionType = "ConnectionTemplatesRendered"
+
+	ReasonTemplatesRendered xpv1.ConditionReason = "TemplatesRendered"
+	ReasonTemplateError     xpv1.ConditionReason = "TemplateError"
+)
+
+// ConnectionTemplatesRendered returns a condition indicating that the
+// connection templates of an AccessKey or User were rendered.
+func ConnectionTemplatesRendered() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConnectionTemplatesRendered,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTemplatesRendered,
+	}
+}
+
+// ConnectionTemplateError returns a condition indicating that a connection
+// template of an AccessKey or User could not be rendered.
+func ConnectionTemplateError(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeConnectionTemplatesRendered,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTemplateError,
+		Message:            err.Error(),
+	}
+}
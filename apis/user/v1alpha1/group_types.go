@@ -18,7 +18,9 @@ package v1alpha1
 
 import (
 	"reflect"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -27,17 +29,16 @@ import (
 
 // GroupParameters are the configurable fields of a Group.
 type GroupParameters struct {
-	// Active determines whether the group is enabled (true) or disabled (false) in the system.
+	// Active determines whether the group is enabled (true) or disabled (false)
+	// in the system. New groups are active if unspecified.
 	//+optional
-	//+kubebuilder:default=true
-	Active bool `json:"active"`
+	Active *bool `json:"active,omitempty"`
 	// GroupName is the group name (known as Description in the GUI).
 	//+optional
 	//+kubebuilder:validation:MaxLength=64
 	GroupName string `json:"groupName,omitempty"`
 	// LDAPEnabled determines whether LDAP authentication is enabled for members of this group.
 	//+optional
-	//+kubebuilder:default=false
 	LDAPEnabled *bool `json:"ldapEnabled,omitempty"`
 	//+optional
 	// LDAPGroup us the group's name from the LDAP system.
@@ -55,10 +56,56 @@ type GroupParameters struct {
 	// LDAPUserDNTemplate specifies how users within this group will be authenticated against the LDAP system when they log into the CMC.
 	//+optional
 	LDAPUserDNTemplate *string `json:"ldapUserDNTemplate,omitempty"`
+	// RatingPlanID is the ID of the rating plan of the group. The rating plan
+	// assigned by Cloudian if unspecified.
+	//+optional
+	RatingPlanID *string `json:"ratingPlanId,omitempty"`
+	// RatingPlanIDRef references a rating plan to retrieve its ID.
+	//+optional
+	RatingPlanIDRef *xpv1.Reference `json:"ratingPlanIdRef,omitempty"`
+	// RatingPlanIDSelector selects a rating plan to retrieve its ID.
+	//+optional
+	RatingPlanIDSelector *xpv1.Selector `json:"ratingPlanIdSelector,omitempty"`
+	// MemberDeletionPolicy determines what happens to the users of the group
+	// when the group is deleted. FailIfNotEmpty refuses to delete a group with
+	// users, and lists them in the DeletionBlocked condition. Orphan leaves a
+	// group with users in Cloudian, and deletes an empty group. Cascade deletes
+	// the credentials, QoS limits in every region and users of the group
+	// before the group.
+	//+optional
+	//+kubebuilder:default=FailIfNotEmpty
+	MemberDeletionPolicy MemberDeletionPolicy `json:"memberDeletionPolicy,omitempty"`
 }
 
+// A MemberDeletionPolicy determines what happens to the users of a group when
+// the group is deleted.
+// +kubebuilder:validation:Enum=Orphan;FailIfNotEmpty;Cascade
+type MemberDeletionPolicy string
+
+// Member deletion policies.
+const (
+	// MemberDeletionOrphan leaves a group with users in Cloudian.
+	MemberDeletionOrphan MemberDeletionPolicy = "Orphan"
+	// MemberDeletionFailIfNotEmpty refuses to delete a group with users.
+	MemberDeletionFailIfNotEmpty MemberDeletionPolicy = "FailIfNotEmpty"
+	// MemberDeletionCascade deletes the users of a group with the group.
+	MemberDeletionCascade MemberDeletionPolicy = "Cascade"
+)
+
 // GroupObservation are the observable fields of a Group.
 type GroupObservation struct {
+	Active             bool   `json:"active,omitempty"`
+	GroupName          string `json:"groupName,omitempty"`
+	LDAPEnabled        bool   `json:"ldapEnabled,omitempty"`
+	LDAPGroup          string `json:"ldapGroup,omitempty"`
+	LDAPMatchAttribute string `json:"ldapMatchAttribute,omitempty"`
+	LDAPSearch         string `json:"ldapSearch,omitempty"`
+	LDAPSearchUserBase string `json:"ldapSearchUserBase,omitempty"`
+	LDAPServerURL      string `json:"ldapServerURL,omitempty"`
+	LDAPUserDNTemplate string `json:"ldapUserDNTemplate,omitempty"`
+	RatingPlanID       string `json:"ratingPlanId,omitempty"`
+	// Usage is observed at the usage poll interval of the ProviderConfig.
+	Usage *UsageObservation `json:"usage,omitempty"`
 }
 
 // A GroupSpec defines the desired state of a Group.
@@ -79,6 +126,9 @@ type GroupStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="STORAGE-BYTES",type="integer",JSONPath=".status.atProvider.usage.storageBytes"
+// +kubebuilder:printcolumn:name="OBJECTS",type="integer",JSONPath=".status.atProvider.usage.objectCount"
+// +kubebuilder:printcolumn:name="REQUESTS",type="integer",JSONPath=".status.atProvider.usage.requests",priority=1
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudian}
@@ -99,6 +149,31 @@ type GroupList struct {
 	Items           []Group `json:"items"`
 }
 
+// Condition types and reasons of a Group.
+const (
+	// TypeDeletionBlocked indicates that a Group cannot be deleted.
+	TypeDeletionBlocked xpv1.ConditionType = "DeletionBlocked"
+
+	ReasonGroupNotEmpty xpv1.ConditionReason = "GroupNotEmpty"
+)
+
+// DeletionBlocked returns a condition indicating that a Group cannot be
+// deleted while the listed users are members of it. More users may exist if
+// `more` is true.
+func DeletionBlocked(users []string, more bool) xpv1.Condition {
+	msg := "Group has users: " + strings.Join(users, ", ")
+	if more {
+		msg += " and more"
+	}
+	return xpv1.Condition{
+		Type:               TypeDeletionBlocked,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonGroupNotEmpty,
+		Message:            msg,
+	}
+}
+
 // Group type metadata.
 var (
 	GroupKind             = reflect.TypeOf(Group{}).Name()
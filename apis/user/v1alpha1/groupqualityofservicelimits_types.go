@@ -50,6 +50,7 @@ type GroupQualityOfServiceLimitsParameters struct {
 
 // GroupQualityOfServiceLimitsObservation are the observable fields of a GroupQualityOfServiceLimits.
 type GroupQualityOfServiceLimitsObservation struct {
+	QOS `json:",inline"`
 }
 
 // A GroupQualityOfServiceLimitsSpec defines the desired state of a GroupQualityOfServiceLimits.
@@ -1,6 +1,10 @@
 package v1alpha1
 
-import resource "k8s.io/apimachinery/pkg/api/resource"
+import (
+	"strconv"
+
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
 
 // +kubebuilder:validation:Pattern=`^(0|((0|[1-9][0-9]*)[KMGT]i))$`
 type Quantity string
@@ -19,6 +23,21 @@ func (q *Quantity) ToKiB() (*int64, error) {
 	return &i, nil
 }
 
+// QuantityFromKiB formats a number of KiB as a Quantity, using the largest
+// binary suffix that represents it exactly.
+func QuantityFromKiB(kib int64) Quantity {
+	if kib == 0 {
+		return "0"
+	}
+	suffixes := []string{"Ki", "Mi", "Gi", "Ti"}
+	i := 0
+	for i < len(suffixes)-1 && kib%1024 == 0 {
+		kib /= 1024
+		i++
+	}
+	return Quantity(strconv.FormatInt(kib, 10) + suffixes[i])
+}
+
 // QualityOfService configures data limits. The value -1 indicates unlimited.
 type QualityOfServiceLimits struct {
 	// StorageQuotaBytes is the limit for total stored data in bytes.
@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// RatingPlanParameters are the configurable fields of a RatingPlan. Usage
+// without tiers is free.
+type RatingPlanParameters struct {
+	// Name is the display name of the rating plan.
+	Name string `json:"name"`
+
+	// Currency of the prices of the rating plan.
+	//+optional
+	//+kubebuilder:default=USD
+	//+kubebuilder:validation:Pattern=`^[A-Z]{3}$`
+	Currency string `json:"currency,omitempty"`
+
+	// Storage is priced per GB-month of stored data.
+	//+optional
+	Storage []RatingTier `json:"storage,omitempty"`
+
+	// DataTransferIn is priced per GB of inbound data.
+	//+optional
+	DataTransferIn []RatingTier `json:"dataTransferIn,omitempty"`
+
+	// DataTransferOut is priced per GB of outbound data.
+	//+optional
+	DataTransferOut []RatingTier `json:"dataTransferOut,omitempty"`
+
+	// GetRequests are priced per 10,000 GET and HEAD requests.
+	//+optional
+	GetRequests []RatingTier `json:"getRequests,omitempty"`
+
+	// PutRequests are priced per 10,000 PUT and POST requests.
+	//+optional
+	PutRequests []RatingTier `json:"putRequests,omitempty"`
+
+	// DeleteRequests are priced per 10,000 DELETE requests.
+	//+optional
+	DeleteRequests []RatingTier `json:"deleteRequests,omitempty"`
+}
+
+// RatingTier prices usage up to an amount. Tiers are ordered by increasing
+// upper bound, and the last tier has no upper bound.
+type RatingTier struct {
+	// UpTo is the upper bound of the tier, in the units of the rating. The
+	// tier has no upper bound if unspecified.
+	//+optional
+	//+kubebuilder:validation:Minimum=1
+	UpTo *int64 `json:"upTo,omitempty"`
+
+	// Price per unit, as a decimal number.
+	//+kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	Price string `json:"price"`
+}
+
+// RatingPlanObservation are the observable fields of a RatingPlan.
+type RatingPlanObservation struct {
+	Name     string `json:"name,omitempty"`
+	Currency string `json:"currency,omitempty"`
+}
+
+// A RatingPlanSpec defines the desired state of a RatingPlan.
+type RatingPlanSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RatingPlanParameters `json:"forProvider"`
+}
+
+// A RatingPlanStatus represents the observed state of a RatingPlan.
+type RatingPlanStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RatingPlanObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RatingPlan represents a Cloudian rating plan, which prices the usage of the
+// groups and users it is assigned to.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudian}
+type RatingPlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RatingPlanSpec   `json:"spec"`
+	Status RatingPlanStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RatingPlanList contains a list of RatingPlan
+type RatingPlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RatingPlan `json:"items"`
+}
+
+// RatingPlan type metadata.
+var (
+	RatingPlanKind             = reflect.TypeOf(RatingPlan{}).Name()
+	RatingPlanGroupKind        = schema.GroupKind{Group: MetadataGroup, Kind: RatingPlanKind}.String()
+	RatingPlanKindAPIVersion   = RatingPlanKind + "." + SchemeGroupVersion.String()
+	RatingPlanGroupVersionKind = SchemeGroupVersion.WithKind(RatingPlanKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&RatingPlan{}, &RatingPlanList{})
+}
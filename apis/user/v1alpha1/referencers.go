@@ -22,6 +22,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reference"
 	resource "github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -43,6 +44,41 @@ func (mg *User) ResolveReferences(ctx context.Context, c client.Reader) error {
 	mg.Spec.ForProvider.GroupID = rsp.ResolvedValue
 	mg.Spec.ForProvider.GroupIDRef = rsp.ResolvedReference
 
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: ptr.Deref(mg.Spec.ForProvider.RatingPlanID, ""),
+		Reference:    mg.Spec.ForProvider.RatingPlanIDRef,
+		Selector:     mg.Spec.ForProvider.RatingPlanIDSelector,
+		To:           reference.To{Managed: &RatingPlan{}, List: &RatingPlanList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to resolve spec.forProvider.ratingPlanId")
+	}
+
+	mg.Spec.ForProvider.RatingPlanID = ptrOrNil(rsp.ResolvedValue)
+	mg.Spec.ForProvider.RatingPlanIDRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this Group
+func (mg *Group) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: ptr.Deref(mg.Spec.ForProvider.RatingPlanID, ""),
+		Reference:    mg.Spec.ForProvider.RatingPlanIDRef,
+		Selector:     mg.Spec.ForProvider.RatingPlanIDSelector,
+		To:           reference.To{Managed: &RatingPlan{}, List: &RatingPlanList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to resolve spec.forProvider.ratingPlanId")
+	}
+
+	mg.Spec.ForProvider.RatingPlanID = ptrOrNil(rsp.ResolvedValue)
+	mg.Spec.ForProvider.RatingPlanIDRef = rsp.ResolvedReference
+
 	return nil
 }
 
@@ -144,3 +180,12 @@ func (mg *UserQualityOfServiceLimits) ResolveReferences(ctx context.Context, c c
 
 	return nil
 }
+
+// ptrOrNil returns a pointer to a resolved optional value, or nil if it is
+// empty.
+func ptrOrNil(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,58 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// UsageObservation is the consumption of a group or user. Request and
+// transfer totals are summed over the usage window of the ProviderConfig.
+type UsageObservation struct {
+	// StorageBytes is the number of bytes stored.
+	StorageBytes int64 `json:"storageBytes"`
+	// ObjectCount is the number of objects stored.
+	ObjectCount int64 `json:"objectCount"`
+	// Requests is the number of GET, PUT and DELETE requests.
+	Requests int64 `json:"requests"`
+	// BytesIn is the number of bytes uploaded.
+	BytesIn int64 `json:"bytesIn"`
+	// BytesOut is the number of bytes downloaded.
+	BytesOut int64 `json:"bytesOut"`
+	// LastUpdated is when the usage was observed.
+	LastUpdated metav1.Time `json:"lastUpdated"`
+}
+
+// Condition types and reasons of the usage of a Group or User.
+const (
+	// TypeUsageObserved indicates whether the usage of a Group or User could
+	// be observed.
+	TypeUsageObserved xpv1.ConditionType = "UsageObserved"
+
+	ReasonUsageObserved xpv1.ConditionReason = "UsageObserved"
+	ReasonUsageError    xpv1.ConditionReason = "UsageError"
+)
+
+// UsageObserved returns a condition indicating that the usage of a Group or
+// User was observed.
+func UsageObserved() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeUsageObserved,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUsageObserved,
+	}
+}
+
+// UsageError returns a condition indicating that the usage of a Group or User
+// could not be observed.
+func UsageError(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeUsageObserved,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUsageError,
+		Message:            err.Error(),
+	}
+}
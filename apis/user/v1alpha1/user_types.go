@@ -40,11 +40,154 @@ type UserParameters struct {
 	// GroupIDSelector selects reference to a group to retrieve its groupId.
 	// +optional
 	GroupIDSelector *xpv1.Selector `json:"groupIdSelector,omitempty"`
+
+	// UserType is the type of the user. System admins must be members of the
+	// system admin group "0". New users are standard users if unspecified, and
+	// the type of an existing user is late-initialized.
+	//+optional
+	//+immutable
+	//+kubebuilder:validation:XValidation:rule="self in ['User', 'GroupAdmin', 'SystemAdmin']",message="userType must be one of User, GroupAdmin and SystemAdmin"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="userType is immutable"
+	UserType string `json:"userType,omitempty"`
+
+	// Active determines whether the user is enabled (true) or disabled (false)
+	// in the system. New users are active if unspecified.
+	//+optional
+	Active *bool `json:"active,omitempty"`
+	// FullName is the full name of the user.
+	//+optional
+	FullName *string `json:"fullName,omitempty"`
+	// EmailAddr is the email address of the user.
+	//+optional
+	EmailAddr *string `json:"emailAddr,omitempty"`
+	//+optional
+	Address1 *string `json:"address1,omitempty"`
+	//+optional
+	Address2 *string `json:"address2,omitempty"`
+	//+optional
+	City *string `json:"city,omitempty"`
+	//+optional
+	State *string `json:"state,omitempty"`
+	//+optional
+	Zip *string `json:"zip,omitempty"`
+	//+optional
+	Country *string `json:"country,omitempty"`
+	//+optional
+	Phone *string `json:"phone,omitempty"`
+	// LDAPEnabled determines whether the user is authenticated against the LDAP system of its group.
+	//+optional
+	LDAPEnabled *bool `json:"ldapEnabled,omitempty"`
+	// RatingPlanID is the ID of the rating plan of the user. The rating plan
+	// assigned by Cloudian if unspecified.
+	//+optional
+	RatingPlanID *string `json:"ratingPlanId,omitempty"`
+	// RatingPlanIDRef references a rating plan to retrieve its ID.
+	//+optional
+	RatingPlanIDRef *xpv1.Reference `json:"ratingPlanIdRef,omitempty"`
+	// RatingPlanIDSelector selects a rating plan to retrieve its ID.
+	//+optional
+	RatingPlanIDSelector *xpv1.Selector `json:"ratingPlanIdSelector,omitempty"`
+	// PurgeOnDelete deletes all buckets and objects of the user, and its
+	// access keys, when the user is deleted. As a safety latch, the user is
+	// only purged if the user.cloudian.crossplane.io/confirm-purge annotation
+	// is set to the user ID. Otherwise users with access keys are not deleted.
+	//+optional
+	PurgeOnDelete bool `json:"purgeOnDelete,omitempty"`
+	// InitialAccessKeyPolicy determines what happens to the access key that
+	// Cloudian creates with the user. Delete deletes it, so that the user only
+	// has access keys managed by AccessKey resources. Keep keeps it, and
+	// Publish also writes it to the connection secret of the User. A kept
+	// access key is deleted with the user. Whether the access key is deleted
+	// or kept is decided when the user is created, while a kept access key can
+	// be published or not later.
+	//+optional
+	//+kubebuilder:default=Delete
+	InitialAccessKeyPolicy InitialAccessKeyPolicy `json:"initialAccessKeyPolicy,omitempty"`
+	// PasswordSecretRef references a Secret key holding the password the user
+	// logs into the CMC with. The password is set again when the Secret
+	// changes, which is noticed at the next poll.
+	//+optional
+	PasswordSecretRef *xpv1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+	// GeneratePassword generates a random password the user logs into the CMC
+	// with, and publishes it in the password key of the connection secret of
+	// the User. Ignored if PasswordSecretRef is set.
+	//+optional
+	GeneratePassword bool `json:"generatePassword,omitempty"`
+	// ConnectionTemplates are Go text/templates rendered to the connection
+	// secret, keyed by the connection secret key they are published to.
+	// Templates can refer to .UserID, .GroupID, .CanonicalID, .Endpoint,
+	// .S3Endpoint and .Region, and to .AccessKey and .SecretKey if the
+	// initial access key is published, and quote values with the json
+	// function. A generated password is not available to templates.
+	// +optional
+	ConnectionTemplates map[string]string `json:"connectionTemplates,omitempty"`
 }
 
+// An InitialAccessKeyPolicy determines what happens to the access key that
+// Cloudian creates with a user.
+// +kubebuilder:validation:Enum=Delete;Keep;Publish
+type InitialAccessKeyPolicy string
+
+// Initial access key policies.
+const (
+	// InitialAccessKeyDelete deletes the initial access key.
+	InitialAccessKeyDelete InitialAccessKeyPolicy = "Delete"
+	// InitialAccessKeyKeep keeps the initial access key.
+	InitialAccessKeyKeep InitialAccessKeyPolicy = "Keep"
+	// InitialAccessKeyPublish keeps the initial access key, and publishes it
+	// to the connection secret of the User.
+	InitialAccessKeyPublish InitialAccessKeyPolicy = "Publish"
+)
+
+// AnnotationConfirmPurge must be set to the user ID of a User to confirm that
+// its data is purged when it is deleted with PurgeOnDelete.
+const AnnotationConfirmPurge = MetadataGroup + "/confirm-purge"
+
+// AnnotationInitialAccessKey records the access key Cloudian created with the
+// user of a User, if it was kept. The status of a managed resource set while
+// its external resource is created is not persisted, unlike its annotations.
+const AnnotationInitialAccessKey = MetadataGroup + "/initial-access-key"
+
 // UserObservation are the observable fields of a User.
 type UserObservation struct {
-	CanonicalID string `json:"canonicalId,omitempty"`
+	CanonicalID  string `json:"canonicalId,omitempty"`
+	UserType     string `json:"userType,omitempty"`
+	Active       bool   `json:"active,omitempty"`
+	FullName     string `json:"fullName,omitempty"`
+	EmailAddr    string `json:"emailAddr,omitempty"`
+	Address1     string `json:"address1,omitempty"`
+	Address2     string `json:"address2,omitempty"`
+	City         string `json:"city,omitempty"`
+	State        string `json:"state,omitempty"`
+	Zip          string `json:"zip,omitempty"`
+	Country      string `json:"country,omitempty"`
+	Phone        string `json:"phone,omitempty"`
+	LDAPEnabled  bool   `json:"ldapEnabled,omitempty"`
+	RatingPlanID string `json:"ratingPlanId,omitempty"`
+	// Usage is observed at the usage poll interval of the ProviderConfig.
+	Usage *UsageObservation `json:"usage,omitempty"`
+	// Purge is the progress of purging the data of the user while it is
+	// deleted.
+	Purge *PurgeObservation `json:"purge,omitempty"`
+	// InitialAccessKey is the access key Cloudian created with the user, if
+	// it was kept and still exists.
+	InitialAccessKey string `json:"initialAccessKey,omitempty"`
+	// PasswordVersion is an HMAC-SHA256 of the password the CMC password was
+	// set from, keyed by the UID of the User, or Generated if it was
+	// generated, to notice when the password changes.
+	PasswordVersion string `json:"passwordVersion,omitempty"`
+}
+
+// PurgeObservation is the progress of purging the data of a user.
+type PurgeObservation struct {
+	// StartTime is when the purge was started.
+	StartTime metav1.Time `json:"startTime"`
+	// RemainingBytes is the number of bytes left to delete.
+	RemainingBytes int64 `json:"remainingBytes"`
+	// RemainingObjects is the number of objects left to delete.
+	RemainingObjects int64 `json:"remainingObjects"`
+	// LastUpdated is when the progress was observed.
+	LastUpdated metav1.Time `json:"lastUpdated"`
 }
 
 // A UserSpec defines the desired state of a User.
@@ -65,6 +208,10 @@ type UserStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="STORAGE-BYTES",type="integer",JSONPath=".status.atProvider.usage.storageBytes"
+// +kubebuilder:printcolumn:name="OBJECTS",type="integer",JSONPath=".status.atProvider.usage.objectCount"
+// +kubebuilder:printcolumn:name="REQUESTS",type="integer",JSONPath=".status.atProvider.usage.requests",priority=1
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".status.atProvider.userType"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudian}
@@ -55,6 +55,7 @@ type UserQualityOfServiceLimitsParameters struct {
 
 // UserQualityOfServiceLimitsObservation are the observable fields of a UserQualityOfServiceLimits.
 type UserQualityOfServiceLimitsObservation struct {
+	QOS `json:",inline"`
 }
 
 // A UserQualityOfServiceLimitsSpec defines the desired state of a UserQualityOfServiceLimits.
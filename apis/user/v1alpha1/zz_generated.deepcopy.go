@@ -87,6 +87,14 @@ func (in *AccessKeyList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessKeyObservation) DeepCopyInto(out *AccessKeyObservation) {
 	*out = *in
+	if in.CreatedAt != nil {
+		in, out := &in.CreatedAt, &out.CreatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.PreviousCreatedAt != nil {
+		in, out := &in.PreviousCreatedAt, &out.PreviousCreatedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessKeyObservation.
@@ -112,6 +120,23 @@ func (in *AccessKeyParameters) DeepCopyInto(out *AccessKeyParameters) {
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(AccessKeyRotation)
+		**out = **in
+	}
+	if in.ConnectionFormats != nil {
+		in, out := &in.ConnectionFormats, &out.ConnectionFormats
+		*out = make([]ConnectionFormat, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConnectionTemplates != nil {
+		in, out := &in.ConnectionTemplates, &out.ConnectionTemplates
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessKeyParameters.
@@ -124,6 +149,24 @@ func (in *AccessKeyParameters) DeepCopy() *AccessKeyParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessKeyRotation) DeepCopyInto(out *AccessKeyRotation) {
+	*out = *in
+	out.MaxAge = in.MaxAge
+	out.OverlapPeriod = in.OverlapPeriod
+	out.GracePeriod = in.GracePeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessKeyRotation.
+func (in *AccessKeyRotation) DeepCopy() *AccessKeyRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessKeyRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessKeySpec) DeepCopyInto(out *AccessKeySpec) {
 	*out = *in
@@ -145,7 +188,7 @@ func (in *AccessKeySpec) DeepCopy() *AccessKeySpec {
 func (in *AccessKeyStatus) DeepCopyInto(out *AccessKeyStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessKeyStatus.
@@ -220,6 +263,11 @@ func (in *GroupList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GroupObservation) DeepCopyInto(out *GroupObservation) {
 	*out = *in
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(UsageObservation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupObservation.
@@ -235,6 +283,11 @@ func (in *GroupObservation) DeepCopy() *GroupObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GroupParameters) DeepCopyInto(out *GroupParameters) {
 	*out = *in
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = new(bool)
+		**out = **in
+	}
 	if in.LDAPEnabled != nil {
 		in, out := &in.LDAPEnabled, &out.LDAPEnabled
 		*out = new(bool)
@@ -270,6 +323,21 @@ func (in *GroupParameters) DeepCopyInto(out *GroupParameters) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.RatingPlanID != nil {
+		in, out := &in.RatingPlanID, &out.RatingPlanID
+		*out = new(string)
+		**out = **in
+	}
+	if in.RatingPlanIDRef != nil {
+		in, out := &in.RatingPlanIDRef, &out.RatingPlanIDRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RatingPlanIDSelector != nil {
+		in, out := &in.RatingPlanIDSelector, &out.RatingPlanIDSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupParameters.
@@ -344,6 +412,7 @@ func (in *GroupQualityOfServiceLimitsList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GroupQualityOfServiceLimitsObservation) DeepCopyInto(out *GroupQualityOfServiceLimitsObservation) {
 	*out = *in
+	in.QOS.DeepCopyInto(&out.QOS)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupQualityOfServiceLimitsObservation.
@@ -403,7 +472,7 @@ func (in *GroupQualityOfServiceLimitsSpec) DeepCopy() *GroupQualityOfServiceLimi
 func (in *GroupQualityOfServiceLimitsStatus) DeepCopyInto(out *GroupQualityOfServiceLimitsStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupQualityOfServiceLimitsStatus.
@@ -437,7 +506,7 @@ func (in *GroupSpec) DeepCopy() *GroupSpec {
 func (in *GroupStatus) DeepCopyInto(out *GroupStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupStatus.
@@ -450,6 +519,23 @@ func (in *GroupStatus) DeepCopy() *GroupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PurgeObservation) DeepCopyInto(out *PurgeObservation) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PurgeObservation.
+func (in *PurgeObservation) DeepCopy() *PurgeObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(PurgeObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QOS) DeepCopyInto(out *QOS) {
 	*out = *in
@@ -515,6 +601,207 @@ func (in *QualityOfServiceLimits) DeepCopy() *QualityOfServiceLimits {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RatingPlan) DeepCopyInto(out *RatingPlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RatingPlan.
+func (in *RatingPlan) DeepCopy() *RatingPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(RatingPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RatingPlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RatingPlanList) DeepCopyInto(out *RatingPlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RatingPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RatingPlanList.
+func (in *RatingPlanList) DeepCopy() *RatingPlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(RatingPlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RatingPlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RatingPlanObservation) DeepCopyInto(out *RatingPlanObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RatingPlanObservation.
+func (in *RatingPlanObservation) DeepCopy() *RatingPlanObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RatingPlanObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RatingPlanParameters) DeepCopyInto(out *RatingPlanParameters) {
+	*out = *in
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = make([]RatingTier, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DataTransferIn != nil {
+		in, out := &in.DataTransferIn, &out.DataTransferIn
+		*out = make([]RatingTier, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DataTransferOut != nil {
+		in, out := &in.DataTransferOut, &out.DataTransferOut
+		*out = make([]RatingTier, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GetRequests != nil {
+		in, out := &in.GetRequests, &out.GetRequests
+		*out = make([]RatingTier, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PutRequests != nil {
+		in, out := &in.PutRequests, &out.PutRequests
+		*out = make([]RatingTier, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DeleteRequests != nil {
+		in, out := &in.DeleteRequests, &out.DeleteRequests
+		*out = make([]RatingTier, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RatingPlanParameters.
+func (in *RatingPlanParameters) DeepCopy() *RatingPlanParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RatingPlanParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RatingPlanSpec) DeepCopyInto(out *RatingPlanSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RatingPlanSpec.
+func (in *RatingPlanSpec) DeepCopy() *RatingPlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RatingPlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RatingPlanStatus) DeepCopyInto(out *RatingPlanStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RatingPlanStatus.
+func (in *RatingPlanStatus) DeepCopy() *RatingPlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RatingPlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RatingTier) DeepCopyInto(out *RatingTier) {
+	*out = *in
+	if in.UpTo != nil {
+		in, out := &in.UpTo, &out.UpTo
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RatingTier.
+func (in *RatingTier) DeepCopy() *RatingTier {
+	if in == nil {
+		return nil
+	}
+	out := new(RatingTier)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageObservation) DeepCopyInto(out *UsageObservation) {
+	*out = *in
+	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageObservation.
+func (in *UsageObservation) DeepCopy() *UsageObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *User) DeepCopyInto(out *User) {
 	*out = *in
@@ -577,6 +864,16 @@ func (in *UserList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserObservation) DeepCopyInto(out *UserObservation) {
 	*out = *in
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(UsageObservation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Purge != nil {
+		in, out := &in.Purge, &out.Purge
+		*out = new(PurgeObservation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserObservation.
@@ -602,6 +899,88 @@ func (in *UserParameters) DeepCopyInto(out *UserParameters) {
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FullName != nil {
+		in, out := &in.FullName, &out.FullName
+		*out = new(string)
+		**out = **in
+	}
+	if in.EmailAddr != nil {
+		in, out := &in.EmailAddr, &out.EmailAddr
+		*out = new(string)
+		**out = **in
+	}
+	if in.Address1 != nil {
+		in, out := &in.Address1, &out.Address1
+		*out = new(string)
+		**out = **in
+	}
+	if in.Address2 != nil {
+		in, out := &in.Address2, &out.Address2
+		*out = new(string)
+		**out = **in
+	}
+	if in.City != nil {
+		in, out := &in.City, &out.City
+		*out = new(string)
+		**out = **in
+	}
+	if in.State != nil {
+		in, out := &in.State, &out.State
+		*out = new(string)
+		**out = **in
+	}
+	if in.Zip != nil {
+		in, out := &in.Zip, &out.Zip
+		*out = new(string)
+		**out = **in
+	}
+	if in.Country != nil {
+		in, out := &in.Country, &out.Country
+		*out = new(string)
+		**out = **in
+	}
+	if in.Phone != nil {
+		in, out := &in.Phone, &out.Phone
+		*out = new(string)
+		**out = **in
+	}
+	if in.LDAPEnabled != nil {
+		in, out := &in.LDAPEnabled, &out.LDAPEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RatingPlanID != nil {
+		in, out := &in.RatingPlanID, &out.RatingPlanID
+		*out = new(string)
+		**out = **in
+	}
+	if in.RatingPlanIDRef != nil {
+		in, out := &in.RatingPlanIDRef, &out.RatingPlanIDRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RatingPlanIDSelector != nil {
+		in, out := &in.RatingPlanIDSelector, &out.RatingPlanIDSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.ConnectionTemplates != nil {
+		in, out := &in.ConnectionTemplates, &out.ConnectionTemplates
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserParameters.
@@ -676,6 +1055,7 @@ func (in *UserQualityOfServiceLimitsList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserQualityOfServiceLimitsObservation) DeepCopyInto(out *UserQualityOfServiceLimitsObservation) {
 	*out = *in
+	in.QOS.DeepCopyInto(&out.QOS)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserQualityOfServiceLimitsObservation.
@@ -735,7 +1115,7 @@ func (in *UserQualityOfServiceLimitsSpec) DeepCopy() *UserQualityOfServiceLimits
 func (in *UserQualityOfServiceLimitsStatus) DeepCopyInto(out *UserQualityOfServiceLimitsStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserQualityOfServiceLimitsStatus.
@@ -769,7 +1149,7 @@ func (in *UserSpec) DeepCopy() *UserSpec {
 func (in *UserStatus) DeepCopyInto(out *UserStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserStatus.
@@ -46,6 +46,15 @@ func (l *GroupQualityOfServiceLimitsList) GetItems() []resource.Managed {
 	return items
 }
 
+// GetItems of this RatingPlanList.
+func (l *RatingPlanList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
 // GetItems of this UserList.
 func (l *UserList) GetItems() []resource.Managed {
 	items := make([]resource.Managed, len(l.Items))
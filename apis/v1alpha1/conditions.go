@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Condition types and reasons of managed resources reconciled through the
+// Cloudian API.
+const (
+	// TypeCloudianAPI indicates whether the last request of a managed
+	// resource to the Cloudian API succeeded, or why it failed.
+	TypeCloudianAPI xpv1.ConditionType = "CloudianAPI"
+
+	ReasonAPIAvailable   xpv1.ConditionReason = "Available"
+	ReasonAPIUnavailable xpv1.ConditionReason = "Unavailable"
+	ReasonUnauthorized   xpv1.ConditionReason = "Unauthorized"
+	ReasonForbidden      xpv1.ConditionReason = "Forbidden"
+	ReasonConflict       xpv1.ConditionReason = "Conflict"
+)
+
+// APIAvailable returns a condition indicating that the last request of a
+// managed resource to the Cloudian API succeeded.
+func APIAvailable() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCloudianAPI,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAPIAvailable,
+	}
+}
+
+// APIError returns a condition indicating why the last request of a managed
+// resource to the Cloudian API failed.
+func APIError(reason xpv1.ConditionReason, err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCloudianAPI,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            err.Error(),
+	}
+}
@@ -19,6 +19,8 @@ package v1alpha1
 import (
 	"reflect"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -26,11 +28,175 @@ import (
 )
 
 // A ProviderConfigSpec defines the desired state of a ProviderConfig.
+// +kubebuilder:validation:XValidation:rule="has(self.authHeader) != has(self.credentials)",message="exactly one of authHeader and credentials must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.endpoint) != has(self.endpoints)",message="exactly one of endpoint and endpoints must be set"
 type ProviderConfigSpec struct {
 	// Endpoint is an url with protocol, hostname and port (no slash at the end) of the Cloudian API.
-	Endpoint string `json:"endpoint"`
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// Endpoints are urls of the Cloudian API on several nodes of the cluster.
+	// Requests fail over between them while an endpoint is unreachable or
+	// responds with 5xx errors.
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	Endpoints []string `json:"endpoints,omitempty"`
+	// EndpointPolicy selects which healthy endpoint requests are sent to.
+	// Priority sends them to the first healthy endpoint in the list, while
+	// RoundRobin spreads them over all healthy endpoints.
+	// +optional
+	// +kubebuilder:validation:Enum=Priority;RoundRobin
+	// +kubebuilder:default=Priority
+	EndpointPolicy string `json:"endpointPolicy,omitempty"`
 	// AuthHeader is the value of the Authorization header in requests to Cloudian API.
-	AuthHeader ProviderCredentials `json:"authHeader"`
+	// Prefer Credentials, from which the header is assembled by the provider.
+	// +optional
+	AuthHeader *ProviderCredentials `json:"authHeader,omitempty"`
+	// Credentials are the username and password used to authenticate to the Cloudian API.
+	// +optional
+	Credentials *UserCredentials `json:"credentials,omitempty"`
+	// TLS configures how the certificate of the Cloudian API is verified.
+	// The system root CAs are used when unspecified.
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty"`
+	// S3 describes the S3 service of the Cloudian cluster, which is published
+	// in the connection secrets of access keys.
+	// +optional
+	S3 *S3Config `json:"s3,omitempty"`
+	// Usage configures how the usage of groups and users is observed.
+	// +optional
+	Usage *UsageConfig `json:"usage,omitempty"`
+	// Client tunes timeouts, retries, circuit breaking and rate limiting of
+	// requests to the Cloudian API.
+	// +optional
+	Client *ClientConfig `json:"client,omitempty"`
+}
+
+// ClientConfig tunes how requests to the Cloudian API are made. Unset fields
+// take their default.
+type ClientConfig struct {
+	// Timeout of each attempt of a request.
+	// +optional
+	// +kubebuilder:default="30s"
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Retries is how many times GET, HEAD and DELETE requests are retried
+	// after a connection error, 429 or 5xx response. Other requests are not
+	// retried, as Cloudian creates resources with PUT and POST.
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	Retries *int32 `json:"retries,omitempty"`
+	// RetryWait is the wait before the first retry, which grows exponentially
+	// up to RetryMaxWait.
+	// +optional
+	// +kubebuilder:default="500ms"
+	RetryWait *metav1.Duration `json:"retryWait,omitempty"`
+	// RetryMaxWait is the longest wait between retries.
+	// +optional
+	// +kubebuilder:default="10s"
+	RetryMaxWait *metav1.Duration `json:"retryMaxWait,omitempty"`
+	// CircuitBreaker fails requests fast while the Cloudian API is down.
+	// +optional
+	CircuitBreaker *CircuitBreakerConfig `json:"circuitBreaker,omitempty"`
+	// RateLimit limits the rate of requests to the Cloudian API, shared by all
+	// controllers using the ProviderConfig.
+	// +optional
+	RateLimit *RateLimitConfig `json:"rateLimit,omitempty"`
+}
+
+// RateLimitConfig configures a token bucket limiting the rate of requests to
+// the Cloudian API. Requests wait until a token is available.
+type RateLimitConfig struct {
+	// RequestsPerSecond is the average rate of requests, which can be below
+	// one request per second, e.g. 0.5 or 500m. Requests are not rate limited
+	// if zero.
+	// +optional
+	// +kubebuilder:default="10"
+	// +kubebuilder:validation:XValidation:rule="!string(self).startsWith('-')",message="requestsPerSecond must not be negative"
+	RequestsPerSecond *resource.Quantity `json:"requestsPerSecond,omitempty"`
+	// Burst is how many requests can be made at once after a quiet period.
+	// +optional
+	// +kubebuilder:default=20
+	// +kubebuilder:validation:Minimum=1
+	Burst *int32 `json:"burst,omitempty"`
+}
+
+// CircuitBreakerConfig configures when requests to the Cloudian API fail
+// fast.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is how many consecutive requests must fail with a
+	// connection error or 5xx response for requests to fail fast. The
+	// circuit breaker is disabled if zero.
+	// +optional
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=0
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+	// OpenDuration is how long requests fail fast, before a single request is
+	// let through to probe whether the Cloudian API has recovered.
+	// +optional
+	// +kubebuilder:default="30s"
+	OpenDuration *metav1.Duration `json:"openDuration,omitempty"`
+}
+
+// UsageConfig configures observation of the usage of groups and users.
+type UsageConfig struct {
+	// PollInterval is how often usage is observed. Observing usage is more
+	// expensive than observing a resource, so it is done less often. Usage is
+	// not observed if zero.
+	// +optional
+	// +kubebuilder:default="15m"
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+	// Window is the period over which request and transfer totals are summed.
+	// +optional
+	// +kubebuilder:default="24h"
+	Window *metav1.Duration `json:"window,omitempty"`
+}
+
+// S3Config describes how S3 clients reach the Cloudian cluster.
+type S3Config struct {
+	// Endpoint is an url with protocol, hostname and port (no slash at the end) of the S3 service.
+	// +kubebuilder:validation:Pattern=`^https?://[^/]+$`
+	Endpoint string `json:"endpoint"`
+	// Region is the S3 region of the Cloudian cluster.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// TLS configures trust of the S3 service certificate. It is separate from
+	// the TLS configuration of the Cloudian API, and system CAs are trusted if
+	// unset.
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty"`
+}
+
+// TLSConfig configures trust of a Cloudian server certificate.
+// +kubebuilder:validation:XValidation:rule="[has(self.caBundle), has(self.caBundleSecretRef), has(self.caBundleConfigMapRef)].filter(x, x).size() <= 1",message="at most one of caBundle, caBundleSecretRef and caBundleConfigMapRef can be set"
+type TLSConfig struct {
+	// CABundle is a PEM encoded bundle of CA certificates trusted to sign the
+	// Cloudian API server certificate.
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+	// CABundleSecretRef references a Secret key holding a PEM encoded CA bundle.
+	// +optional
+	CABundleSecretRef *xpv1.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
+	// CABundleConfigMapRef references a ConfigMap key holding a PEM encoded CA bundle.
+	// +optional
+	CABundleConfigMapRef *ConfigMapKeySelector `json:"caBundleConfigMapRef,omitempty"`
+	// ServerName overrides the host name used to verify the server certificate.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+	// InsecureSkipVerify disables verification of the server certificate.
+	// This should only be used for testing.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// A ConfigMapKeySelector is a reference to a ConfigMap key in an arbitrary namespace.
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+	// The key to select.
+	Key string `json:"key"`
 }
 
 // ProviderCredentials required to authenticate.
@@ -42,9 +208,107 @@ type ProviderCredentials struct {
 	xpv1.CommonCredentialSelectors `json:",inline"`
 }
 
+// UserCredentials are the username and password of a Cloudian API user.
+// +kubebuilder:validation:XValidation:rule="self.source != 'Secret' || has(self.secretRef) || has(self.usernamePasswordSecretRef)",message="secretRef or usernamePasswordSecretRef is required when source is Secret"
+// +kubebuilder:validation:XValidation:rule="self.source == 'Secret' || !has(self.usernamePasswordSecretRef)",message="usernamePasswordSecretRef requires source Secret"
+type UserCredentials struct {
+	// Source of the credentials. The selected secret key, environment variable
+	// or file must hold a JSON document with "username" and "password" fields,
+	// unless usernamePasswordSecretRef is used.
+	// +kubebuilder:validation:Enum=Secret;Environment;Filesystem
+	Source xpv1.CredentialsSource `json:"source"`
+
+	xpv1.CommonCredentialSelectors `json:",inline"`
+
+	// UsernamePasswordSecretRef references a Secret holding the username and
+	// password in separate keys. Only used when source is Secret.
+	// +optional
+	UsernamePasswordSecretRef *UsernamePasswordSecretReference `json:"usernamePasswordSecretRef,omitempty"`
+}
+
+// A UsernamePasswordSecretReference is a reference to a Secret holding a username and password.
+type UsernamePasswordSecretReference struct {
+	xpv1.SecretReference `json:",inline"`
+
+	// UsernameKey is the key of the Secret holding the username.
+	// +optional
+	// +kubebuilder:default=username
+	UsernameKey string `json:"usernameKey,omitempty"`
+
+	// PasswordKey is the key of the Secret holding the password.
+	// +optional
+	// +kubebuilder:default=password
+	PasswordKey string `json:"passwordKey,omitempty"`
+}
+
+// GetEndpoints returns the urls of the Cloudian API, whether configured as a
+// single endpoint or several.
+func (s ProviderConfigSpec) GetEndpoints() []string {
+	if len(s.Endpoints) > 0 {
+		return s.Endpoints
+	}
+	return []string{s.Endpoint}
+}
+
 // A ProviderConfigStatus reflects the observed state of a ProviderConfig.
 type ProviderConfigStatus struct {
 	xpv1.ProviderConfigStatus `json:",inline"`
+
+	// ActiveEndpoint is the endpoint requests are currently sent to. It is
+	// unset with the RoundRobin policy.
+	// +optional
+	ActiveEndpoint string `json:"activeEndpoint,omitempty"`
+	// Endpoints is the health of each endpoint of the Cloudian API.
+	// +optional
+	Endpoints []EndpointStatus `json:"endpoints,omitempty"`
+}
+
+// EndpointStatus is the observed health of an endpoint of the Cloudian API.
+type EndpointStatus struct {
+	// URL of the endpoint.
+	URL string `json:"url"`
+	// Healthy is false while the endpoint is unreachable or responds with 5xx
+	// errors.
+	Healthy bool `json:"healthy"`
+	// Message is the last error of an unhealthy endpoint.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is when the endpoint last became healthy or
+	// unhealthy.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// Condition types and reasons of a ProviderConfig.
+const (
+	// TypeTLSValid indicates whether the TLS configuration can be loaded.
+	TypeTLSValid xpv1.ConditionType = "TLSValid"
+
+	ReasonTLSValid   xpv1.ConditionReason = "ValidTLSConfig"
+	ReasonTLSInvalid xpv1.ConditionReason = "InvalidTLSConfig"
+)
+
+// TLSValid returns a condition indicating that the TLS configuration of a
+// ProviderConfig could be loaded.
+func TLSValid() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTLSValid,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTLSValid,
+	}
+}
+
+// TLSInvalid returns a condition indicating that the TLS configuration of a
+// ProviderConfig could not be loaded.
+func TLSInvalid(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTLSValid,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTLSInvalid,
+		Message:            err.Error(),
+	}
 }
 
 // +kubebuilder:object:root=true
@@ -52,6 +316,7 @@ type ProviderConfigStatus struct {
 // A ProviderConfig configures a Cloudian provider.
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="ACTIVE-ENDPOINT",type="string",JSONPath=".status.activeEndpoint"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentials.secretRef.name",priority=1
 // +kubebuilder:resource:scope=Cluster
 type ProviderConfig struct {
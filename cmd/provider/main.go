@@ -29,6 +29,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
@@ -41,6 +42,8 @@ import (
 	"github.com/statnett/provider-cloudian/apis/v1alpha1"
 	cloudian "github.com/statnett/provider-cloudian/internal/controller"
 	"github.com/statnett/provider-cloudian/internal/features"
+	"github.com/statnett/provider-cloudian/internal/metrics"
+	sdk "github.com/statnett/provider-cloudian/internal/sdk/cloudian"
 )
 
 func main() {
@@ -56,6 +59,7 @@ func main() {
 		namespace                  = app.Flag("namespace", "Namespace used to set as default scope in default secret store config.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
 		enableExternalSecretStores = app.Flag("enable-external-secret-stores", "Enable support for ExternalSecretStores.").Default("false").Envar("ENABLE_EXTERNAL_SECRET_STORES").Bool()
 		enableManagementPolicies   = app.Flag("enable-management-policies", "Enable support for Management Policies.").Default("false").Envar("ENABLE_MANAGEMENT_POLICIES").Bool()
+		enableTenantMetrics        = app.Flag("enable-tenant-metrics", "Enable metrics of the usage and quality of service limits of groups and users.").Default("false").Envar("ENABLE_TENANT_METRICS").Bool()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -126,6 +130,14 @@ func main() {
 		log.Info("Alpha feature enabled", "flag", features.EnableAlphaManagementPolicies)
 	}
 
+	ctrlmetrics.Registry.MustRegister(sdk.Collectors()...)
+
+	if *enableTenantMetrics {
+		o.Features.Enable(features.EnableAlphaTenantMetrics)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaTenantMetrics)
+		ctrlmetrics.Registry.MustRegister(metrics.DefaultTenants)
+	}
+
 	kingpin.FatalIfError(cloudian.Setup(mgr, o), "Cannot setup Cloudian controllers")
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
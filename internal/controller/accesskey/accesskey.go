@@ -18,12 +18,11 @@ package accesskey
 
 import (
 	"context"
-	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
-	"k8s.io/apimachinery/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/connection"
@@ -36,30 +35,21 @@ import (
 
 	"github.com/statnett/provider-cloudian/apis/user/v1alpha1"
 	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/controller/connectiontemplate"
+	"github.com/statnett/provider-cloudian/internal/controller/connector"
 	"github.com/statnett/provider-cloudian/internal/features"
 	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
 )
 
 const (
 	errNotAccessKey = "managed resource is not a AccessKey custom resource"
-	errTrackPCUsage = "cannot track ProviderConfig usage"
-	errGetPC        = "cannot get ProviderConfig"
 	errCreateCreds  = "cannot create credentials"
 	errGetCreds     = "cannot get credentials"
 	errDeleteCreds  = "cannot delete credentials"
-
-	errNewClient = "cannot create new Service"
-)
-
-var (
-	newCloudianService = func(providerConfig *apisv1alpha1.ProviderConfig, authHeader string) (*cloudian.Client, error) {
-		// FIXME: Don't require InsecureSkipVerify
-		return cloudian.NewClient(
-			providerConfig.Spec.Endpoint,
-			authHeader,
-			cloudian.WithInsecureTLSVerify(true),
-		), nil
-	}
+	errUpdateStatus = "cannot update credentials status"
+	errRotateCreds  = "cannot rotate credentials"
+	errPersistName  = "cannot persist external name of rotated credentials"
+	errGetUser      = "cannot get user of credentials"
 )
 
 // Setup adds a controller that reconciles AccessKey managed resources.
@@ -71,65 +61,35 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
-	r := managed.NewReconciler(mgr,
-		resource.ManagedKind(v1alpha1.AccessKeyGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
-			kube:         mgr.GetClient(),
-			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newServiceFn: newCloudianService}),
+	conn := connector.New(mgr.GetClient(), newExternal(managed.NewRetryingCriticalAnnotationUpdater(mgr.GetClient())))
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(conn),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		managed.WithConnectionPublishers(cps...))
+		managed.WithConnectionPublishers(cps...),
+	}
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.AccessKeyGroupVersionKind), opts...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1alpha1.AccessKey{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, conn.Reconciler(r, o.PollInterval), o.GlobalRateLimiter))
 }
 
-// A connector is expected to produce an ExternalClient when its Connect method
-// is called.
-type connector struct {
-	kube         client.Client
-	usage        resource.Tracker
-	newServiceFn func(providerConfig *apisv1alpha1.ProviderConfig, authHeader string) (*cloudian.Client, error)
-}
-
-// Connect typically produces an ExternalClient by:
-// 1. Tracking that the managed resource is using a ProviderConfig.
-// 2. Getting the managed resource's ProviderConfig.
-// 3. Getting the credentials specified by the ProviderConfig.
-// 4. Using the credentials to form a client.
-func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mg.(*v1alpha1.AccessKey)
-	if !ok {
-		return nil, errors.New(errNotAccessKey)
-	}
-
-	if err := c.usage.Track(ctx, mg); err != nil {
-		return nil, errors.Wrap(err, errTrackPCUsage)
-	}
-
-	pc := &apisv1alpha1.ProviderConfig{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
-		return nil, errors.Wrap(err, errGetPC)
-	}
-
-	cd := pc.Spec.AuthHeader
-	authHeader, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
-	if err != nil {
-		return nil, errors.Wrap(err, errGetCreds)
-	}
-
-	svc, err := c.newServiceFn(pc, string(authHeader))
-	if err != nil {
-		return nil, errors.Wrap(err, errNewClient)
+// newExternal returns a function that produces an ExternalClient from a
+// Cloudian client. The annotation updater persists the external name when an
+// access key is rotated.
+func newExternal(annotations managed.CriticalAnnotationUpdater) connector.NewExternalFn {
+	return func(svc *cloudian.Client, pc *apisv1alpha1.ProviderConfig) managed.ExternalClient {
+		return &external{cloudianService: svc, annotations: annotations, endpoint: pc.Spec.GetEndpoints()[0], s3: pc.Spec.S3}
 	}
-
-	return &external{cloudianService: svc}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
@@ -138,6 +98,9 @@ type external struct {
 	// A 'client' used to connect to the external resource API. In practice this
 	// would be something like an AWS SDK client.
 	cloudianService *cloudian.Client
+	annotations     managed.CriticalAnnotationUpdater
+	endpoint        string
+	s3              *apisv1alpha1.S3Config
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -158,9 +121,30 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.Wrap(err, errGetCreds)
 	}
 
+	now := time.Now()
 	cr.Status.AtProvider.AccessKey = meta.GetExternalName(cr)
+	cr.Status.AtProvider.Active = creds.Active
+	cr.Status.AtProvider.CreatedAt = createdAt(creds, cr.Status.AtProvider.CreatedAt, now)
 	cr.SetConditions(xpv1.Available())
 
+	details := connectionDetails(creds, cr.Spec.ForProvider, c.s3)
+	if cr.Spec.ForProvider.Rotation != nil {
+		var previous *cloudian.SecurityInfo
+		if previousPublished(cr.Spec.ForProvider, cr.Status.AtProvider, now) {
+			previous, err = c.cloudianService.GetUserCredentials(ctx, cr.Status.AtProvider.PreviousAccessKey)
+			if errors.Is(err, cloudian.ErrNotFound) {
+				cr.Status.AtProvider.PreviousAccessKey = ""
+				cr.Status.AtProvider.PreviousCreatedAt = nil
+			} else if err != nil {
+				return managed.ExternalObservation{}, errors.Wrap(err, errGetCreds)
+			}
+		}
+		addPreviousConnectionDetails(details, previous)
+	}
+	if err := c.addTemplateDetails(ctx, cr, creds, details); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	return managed.ExternalObservation{
 		// Return false when the external resource does not exist. This lets
 		// the managed resource reconciler know that it needs to call Create to
@@ -170,12 +154,13 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		// Return false when the external resource exists, but it not up to date
 		// with the desired managed resource state. This lets the managed
 		// resource reconciler know that it needs to call Update.
-		// TODO: Check Inactivate / Activate
-		ResourceUpToDate: true,
+		ResourceUpToDate: creds.Active == cr.Spec.ForProvider.Active &&
+			!rotationDue(cr.Spec.ForProvider, cr.Status.AtProvider, now) &&
+			!previousExpired(cr.Spec.ForProvider, cr.Status.AtProvider, now),
 
 		// Return any details that may be required to connect to the external
 		// resource. These will be stored as the connection secret.
-		ConnectionDetails: connectionDetails(creds),
+		ConnectionDetails: details,
 	}, nil
 }
 
@@ -195,30 +180,142 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreateCreds)
 	}
 
+	// Cloudian creates active credentials. An inactive access key is
+	// deactivated by Update, once Observe has seen the new credentials.
 	meta.SetExternalName(cr, creds.AccessKey)
 
+	details := connectionDetails(creds, cr.Spec.ForProvider, c.s3)
+	if err := c.addTemplateDetails(ctx, cr, creds, details); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
 	return managed.ExternalCreation{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: connectionDetails(creds),
+		ConnectionDetails: details,
 	}, nil
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	_, ok := mg.(*v1alpha1.AccessKey)
+	cr, ok := mg.(*v1alpha1.AccessKey)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotAccessKey)
 	}
 
-	// TODO: Update Inactivate / Activate
+	now := time.Now()
+	if previousExpired(cr.Spec.ForProvider, cr.Status.AtProvider, now) {
+		err := c.cloudianService.DeleteUserCredentials(ctx, cr.Status.AtProvider.PreviousAccessKey)
+		if err != nil && !errors.Is(err, cloudian.ErrNotFound) {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errDeleteCreds)
+		}
+		cr.Status.AtProvider.PreviousAccessKey = ""
+		cr.Status.AtProvider.PreviousCreatedAt = nil
+	}
+
+	details := managed.ConnectionDetails{}
+	if rotationDue(cr.Spec.ForProvider, cr.Status.AtProvider, now) {
+		var err error
+		if details, err = c.rotate(ctx, cr, now); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errRotateCreds)
+		}
+	}
+
+	if cr.Status.AtProvider.Active != cr.Spec.ForProvider.Active {
+		if err := c.cloudianService.UpdateUserCredentialsStatus(ctx, meta.GetExternalName(cr), cr.Spec.ForProvider.Active); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateStatus)
+		}
+		cr.Status.AtProvider.Active = cr.Spec.ForProvider.Active
+	}
 
 	return managed.ExternalUpdate{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: details,
 	}, nil
 }
 
+// rotate replaces the access key of an AccessKey with a new one, keeping the
+// current access key as the previous one. The external name is persisted
+// immediately, because the managed reconciler only persists the status of a
+// resource after an update.
+func (c *external) rotate(ctx context.Context, cr *v1alpha1.AccessKey, now time.Time) (managed.ConnectionDetails, error) {
+	previous, err := c.cloudianService.GetUserCredentials(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	creds, err := c.cloudianService.CreateUserCredentials(ctx, cloudian.GroupUserID{
+		GroupID: cr.Spec.ForProvider.GroupID,
+		UserID:  cr.Spec.ForProvider.UserID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateCreds)
+	}
+
+	// Persisting the annotation replaces the in-memory status with the stored one.
+	status := cr.Status.DeepCopy()
+	meta.SetExternalName(cr, creds.AccessKey)
+	if err := c.annotations.UpdateCriticalAnnotations(ctx, cr); err != nil {
+		return nil, errors.Wrap(err, errPersistName)
+	}
+	cr.Status = *status
+
+	cr.Status.AtProvider.PreviousAccessKey = previous.AccessKey
+	cr.Status.AtProvider.PreviousCreatedAt = cr.Status.AtProvider.CreatedAt
+	cr.Status.AtProvider.AccessKey = creds.AccessKey
+	cr.Status.AtProvider.Active = creds.Active
+	cr.Status.AtProvider.CreatedAt = createdAt(creds, nil, now)
+
+	details := connectionDetails(creds, cr.Spec.ForProvider, c.s3)
+	addPreviousConnectionDetails(details, previous)
+	if err := c.addTemplateDetails(ctx, cr, creds, details); err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+// addTemplateDetails renders the connection templates of an AccessKey into
+// details. Templates that cannot be rendered are reported by a condition,
+// while the remaining connection details are still published.
+func (c *external) addTemplateDetails(ctx context.Context, cr *v1alpha1.AccessKey, creds *cloudian.SecurityInfo, details managed.ConnectionDetails) error {
+	if len(cr.Spec.ForProvider.ConnectionTemplates) == 0 {
+		return nil
+	}
+
+	guid := cloudian.GroupUserID{GroupID: cr.Spec.ForProvider.GroupID, UserID: cr.Spec.ForProvider.UserID}
+	data := connectiontemplate.Data{
+		AccessKey: creds.AccessKey,
+		SecretKey: creds.SecretKey,
+		UserID:    guid.UserID,
+		GroupID:   guid.GroupID,
+		Endpoint:  c.endpoint,
+	}
+	// The user is only fetched for templates that need it, as templates are
+	// rendered at every poll.
+	if connectiontemplate.References(cr.Spec.ForProvider.ConnectionTemplates, "CanonicalID") {
+		user, err := c.cloudianService.GetUser(ctx, guid)
+		if err != nil {
+			return errors.Wrap(err, errGetUser)
+		}
+		data.CanonicalID = user.CanonicalID
+	}
+	if c.s3 != nil {
+		data.S3Endpoint = c.s3.Endpoint
+		data.Region = c.s3.Region
+	}
+
+	rendered, err := connectiontemplate.Render(cr.Spec.ForProvider.ConnectionTemplates, data)
+	if err != nil {
+		cr.SetConditions(v1alpha1.ConnectionTemplateError(err))
+		return nil
+	}
+	cr.SetConditions(v1alpha1.ConnectionTemplatesRendered())
+	for key, value := range rendered {
+		details[key] = value
+	}
+	return nil
+}
+
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
 	cr, ok := mg.(*v1alpha1.AccessKey)
 	if !ok {
@@ -227,9 +324,14 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Deleting())
 
-	err := c.cloudianService.DeleteUserCredentials(ctx, meta.GetExternalName(cr))
-	if err != nil && !errors.Is(err, cloudian.ErrNotFound) {
-		return managed.ExternalDelete{}, errors.Wrap(err, errGetCreds)
+	for _, accessKey := range []string{cr.Status.AtProvider.PreviousAccessKey, meta.GetExternalName(cr)} {
+		if accessKey == "" {
+			continue
+		}
+		err := c.cloudianService.DeleteUserCredentials(ctx, accessKey)
+		if err != nil && !errors.Is(err, cloudian.ErrNotFound) {
+			return managed.ExternalDelete{}, errors.Wrap(err, errDeleteCreds)
+		}
 	}
 
 	return managed.ExternalDelete{}, nil
@@ -239,15 +341,61 @@ func (c *external) Disconnect(ctx context.Context) error {
 	return nil
 }
 
-func connectionDetails(creds *cloudian.SecurityInfo) managed.ConnectionDetails {
-	return managed.ConnectionDetails{
-		"secretKey": []byte(creds.SecretKey),
-		"config.toml": []byte(fmt.Sprintf(
-			`[default]
-aws_access_key_id = %s
-aws_secret_access_key = %s`,
-			creds.AccessKey,
-			creds.SecretKey,
-		)),
+// addPreviousConnectionDetails publishes the previous access key during the
+// overlap period of a rotation. The keys are published empty otherwise, so
+// that the previous access key is removed from the connection secret.
+func addPreviousConnectionDetails(details managed.ConnectionDetails, previous *cloudian.SecurityInfo) {
+	details["previousAccessKey"] = []byte{}
+	details["previousSecretKey"] = []byte{}
+	if previous != nil {
+		details["previousAccessKey"] = []byte(previous.AccessKey)
+		details["previousSecretKey"] = []byte(previous.SecretKey)
+	}
+}
+
+// createdAt returns when credentials were created. Cloudian versions that do
+// not report the creation date fall back to when the credentials were first
+// observed.
+func createdAt(creds *cloudian.SecurityInfo, observed *metav1.Time, now time.Time) *metav1.Time {
+	if !creds.CreateDate.IsZero() {
+		t := metav1.NewTime(creds.CreateDate)
+		return &t
+	}
+	if observed != nil {
+		return observed
+	}
+	t := metav1.NewTime(now)
+	return &t
+}
+
+// rotationDue reports whether the access key has reached its max age. Inactive
+// access keys are not rotated, and neither are access keys whose previous
+// access key has not yet been deleted.
+func rotationDue(params v1alpha1.AccessKeyParameters, obs v1alpha1.AccessKeyObservation, now time.Time) bool {
+	if params.Rotation == nil || !params.Active || obs.PreviousAccessKey != "" || obs.CreatedAt == nil {
+		return false
+	}
+	return !now.Before(obs.CreatedAt.Add(params.Rotation.MaxAge.Duration))
+}
+
+// previousPublished reports whether the previous access key is within the
+// overlap period of the last rotation.
+func previousPublished(params v1alpha1.AccessKeyParameters, obs v1alpha1.AccessKeyObservation, now time.Time) bool {
+	if params.Rotation == nil || obs.PreviousAccessKey == "" || obs.CreatedAt == nil {
+		return false
+	}
+	return now.Before(obs.CreatedAt.Add(params.Rotation.OverlapPeriod.Duration))
+}
+
+// previousExpired reports whether the previous access key has passed both the
+// overlap and grace period of the last rotation, and should be deleted. The
+// previous access key expires immediately if rotation has been disabled.
+func previousExpired(params v1alpha1.AccessKeyParameters, obs v1alpha1.AccessKeyObservation, now time.Time) bool {
+	if obs.PreviousAccessKey == "" {
+		return false
+	}
+	if params.Rotation == nil || obs.CreatedAt == nil {
+		return true
 	}
+	return !now.Before(obs.CreatedAt.Add(params.Rotation.OverlapPeriod.Duration + params.Rotation.GracePeriod.Duration))
 }
@@ -18,13 +18,23 @@ package accesskey
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
-	"github.com/crossplane/crossplane-runtime/pkg/resource"
-	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/statnett/provider-cloudian/apis/user/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -35,38 +45,451 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
+// adminAPI fakes the credentials operations of the Cloudian admin API. Access
+// keys map to their creation time, and created access keys are named "new".
+type adminAPI struct {
+	keys     map[string]time.Time
+	requests []string
+}
+
+func (a *adminAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := r.URL.Query().Get("accessKey")
+	a.requests = append(a.requests, strings.TrimSpace(r.Method+" "+r.URL.Path+" "+key))
+	switch {
+	case r.Method == http.MethodPut && r.URL.Path == "/user/credentials":
+		key = "new"
+		a.keys[key] = time.Now()
+	case r.Method == http.MethodDelete:
+		delete(a.keys, key)
+		return
+	case r.Method != http.MethodGet:
+		return
+	}
+	created, ok := a.keys[key]
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{
+		"accessKey":  key,
+		"secretKey":  key + "-secret",
+		"active":     true,
+		"createDate": created.UnixMilli(),
+	})
+}
+
 func TestObserve(t *testing.T) {
-	type fields struct {
+	now := time.Now()
+	rotation := &v1alpha1.AccessKeyRotation{
+		MaxAge:        metav1.Duration{Duration: 30 * 24 * time.Hour},
+		OverlapPeriod: metav1.Duration{Duration: 24 * time.Hour},
+		GracePeriod:   metav1.Duration{Duration: time.Hour},
 	}
 
-	type args struct {
-		ctx context.Context
-		mg  resource.Managed
+	type want struct {
+		exists   bool
+		upToDate bool
+		previous string
+		details  map[string]string
+	}
+
+	cases := map[string]struct {
+		reason   string
+		keys     map[string]time.Time
+		rotation *v1alpha1.AccessKeyRotation
+		previous string
+		want     want
+	}{
+		"NotFound": {
+			reason: "An access key that does not exist should not be reported as existing.",
+			keys:   map[string]time.Time{},
+		},
+		"UpToDate": {
+			reason:   "An active access key younger than its max age should be up to date.",
+			keys:     map[string]time.Time{"key": now.Add(-time.Hour)},
+			rotation: rotation,
+			want: want{
+				exists:   true,
+				upToDate: true,
+				details:  map[string]string{"accessKey": "key", "previousAccessKey": ""},
+			},
+		},
+		"RotationDue": {
+			reason:   "An access key that has reached its max age should not be up to date.",
+			keys:     map[string]time.Time{"key": now.AddDate(0, 0, -31)},
+			rotation: rotation,
+			want: want{
+				exists:  true,
+				details: map[string]string{"accessKey": "key", "previousAccessKey": ""},
+			},
+		},
+		"Overlap": {
+			reason:   "The previous access key should be published during the overlap period.",
+			keys:     map[string]time.Time{"key": now.Add(-time.Hour), "old": now.AddDate(0, 0, -31)},
+			rotation: rotation,
+			previous: "old",
+			want: want{
+				exists:   true,
+				upToDate: true,
+				previous: "old",
+				details:  map[string]string{"accessKey": "key", "previousAccessKey": "old"},
+			},
+		},
+		"PreviousGone": {
+			reason:   "A previous access key deleted outside of the provider should be forgotten.",
+			keys:     map[string]time.Time{"key": now.Add(-time.Hour)},
+			rotation: rotation,
+			previous: "old",
+			want: want{
+				exists:   true,
+				upToDate: true,
+				details:  map[string]string{"accessKey": "key", "previousAccessKey": ""},
+			},
+		},
+		"PreviousExpired": {
+			reason:   "An access key whose previous access key has expired should not be up to date.",
+			keys:     map[string]time.Time{"key": now.Add(-26 * time.Hour), "old": now.AddDate(0, 0, -31)},
+			rotation: rotation,
+			previous: "old",
+			want: want{
+				exists:   true,
+				previous: "old",
+				details:  map[string]string{"accessKey": "key", "previousAccessKey": ""},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			api := &adminAPI{keys: tc.keys}
+			admin := httptest.NewServer(api)
+			defer admin.Close()
+
+			cr := &v1alpha1.AccessKey{Spec: v1alpha1.AccessKeySpec{ForProvider: v1alpha1.AccessKeyParameters{Active: true, Rotation: tc.rotation}}}
+			meta.SetExternalName(cr, "key")
+			cr.Status.AtProvider.PreviousAccessKey = tc.previous
+			e := external{cloudianService: cloudian.NewClient(admin.URL, "")}
+
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v\n", tc.reason, err)
+			}
+			details := map[string]string{}
+			for _, k := range []string{"accessKey", "previousAccessKey"} {
+				if v, ok := got.ConnectionDetails[k]; ok {
+					details[k] = string(v)
+				}
+			}
+			if !got.ResourceExists {
+				details = nil
+			}
+			gotWant := want{
+				exists:   got.ResourceExists,
+				upToDate: got.ResourceUpToDate,
+				previous: cr.Status.AtProvider.PreviousAccessKey,
+				details:  details,
+			}
+			if diff := cmp.Diff(tc.want, gotWant, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	now := time.Now()
+	created := metav1.NewTime(now.AddDate(0, 0, -31).Truncate(time.Millisecond))
+	rotation := &v1alpha1.AccessKeyRotation{
+		MaxAge:        metav1.Duration{Duration: 30 * 24 * time.Hour},
+		OverlapPeriod: metav1.Duration{Duration: 24 * time.Hour},
+		GracePeriod:   metav1.Duration{Duration: time.Hour},
 	}
 
 	type want struct {
-		o   managed.ExternalObservation
-		err error
+		requests     []string
+		externalName string
+		status       v1alpha1.AccessKeyObservation
+		details      map[string]string
 	}
 
 	cases := map[string]struct {
 		reason string
-		fields fields
-		args   args
+		keys   map[string]time.Time
+		params v1alpha1.AccessKeyParameters
+		status v1alpha1.AccessKeyObservation
 		want   want
 	}{
-		// TODO: Add test cases.
+		"Rotate": {
+			reason: "An access key past its max age should be replaced, keeping it as the previous access key.",
+			keys:   map[string]time.Time{"key": created.Time},
+			params: v1alpha1.AccessKeyParameters{Active: true, Rotation: rotation},
+			status: v1alpha1.AccessKeyObservation{AccessKey: "key", Active: true, CreatedAt: &created},
+			want: want{
+				requests:     []string{"GET /user/credentials key", "PUT /user/credentials"},
+				externalName: "new",
+				status: v1alpha1.AccessKeyObservation{
+					AccessKey:         "new",
+					Active:            true,
+					PreviousAccessKey: "key",
+					PreviousCreatedAt: &created,
+				},
+				details: map[string]string{"accessKey": "new", "previousAccessKey": "key"},
+			},
+		},
+		"DeletePrevious": {
+			reason: "An expired previous access key should be deleted.",
+			keys:   map[string]time.Time{"key": now.Add(-26 * time.Hour), "old": created.Time},
+			params: v1alpha1.AccessKeyParameters{Active: true, Rotation: rotation},
+			status: v1alpha1.AccessKeyObservation{AccessKey: "key", Active: true, CreatedAt: &metav1.Time{Time: now.Add(-26 * time.Hour)}, PreviousAccessKey: "old", PreviousCreatedAt: &created},
+			want: want{
+				requests:     []string{"DELETE /user/credentials old"},
+				externalName: "key",
+				status:       v1alpha1.AccessKeyObservation{AccessKey: "key", Active: true},
+				details:      map[string]string{},
+			},
+		},
+		"Deactivate": {
+			reason: "An access key that should be inactive should be deactivated.",
+			keys:   map[string]time.Time{"key": now},
+			params: v1alpha1.AccessKeyParameters{Active: false},
+			status: v1alpha1.AccessKeyObservation{AccessKey: "key", Active: true},
+			want: want{
+				requests:     []string{"POST /user/credentials/status key"},
+				externalName: "key",
+				status:       v1alpha1.AccessKeyObservation{AccessKey: "key"},
+				details:      map[string]string{},
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{}
-			got, err := e.Observe(tc.args.ctx, tc.args.mg)
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			api := &adminAPI{keys: tc.keys}
+			admin := httptest.NewServer(api)
+			defer admin.Close()
+
+			cr := &v1alpha1.AccessKey{Spec: v1alpha1.AccessKeySpec{ForProvider: tc.params}}
+			meta.SetExternalName(cr, "key")
+			cr.Status.AtProvider = tc.status
+			e := external{
+				cloudianService: cloudian.NewClient(admin.URL, ""),
+				// Like the API server, updating the annotations resets the
+				// status to the stored one, which is unchanged.
+				annotations: managed.CriticalAnnotationUpdateFn(func(_ context.Context, o client.Object) error {
+					o.(*v1alpha1.AccessKey).Status = v1alpha1.AccessKeyStatus{}
+					return nil
+				}),
 			}
-			if diff := cmp.Diff(tc.want.o, got); diff != "" {
-				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+
+			got, err := e.Update(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Update(...): %v\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.requests, api.requests); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want requests, +got requests:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(cr)); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.status, cr.Status.AtProvider, cmpopts.IgnoreFields(v1alpha1.AccessKeyObservation{}, "CreatedAt")); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+			details := map[string]string{}
+			for _, k := range []string{"accessKey", "previousAccessKey"} {
+				if v, ok := got.ConnectionDetails[k]; ok {
+					details[k] = string(v)
+				}
+			}
+			if diff := cmp.Diff(tc.want.details, details); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want details, +got details:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		keys     map[string]time.Time
+		previous string
+		want     []string
+	}{
+		"AccessKey": {
+			reason: "The access key should be deleted.",
+			keys:   map[string]time.Time{"key": time.Now()},
+			want:   []string{"DELETE /user/credentials key"},
+		},
+		"PreviousAccessKey": {
+			reason:   "The previous access key of a rotation should be deleted along with the access key.",
+			keys:     map[string]time.Time{"key": time.Now(), "old": time.Now()},
+			previous: "old",
+			want:     []string{"DELETE /user/credentials old", "DELETE /user/credentials key"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			api := &adminAPI{keys: tc.keys}
+			admin := httptest.NewServer(api)
+			defer admin.Close()
+
+			cr := &v1alpha1.AccessKey{}
+			meta.SetExternalName(cr, "key")
+			cr.Status.AtProvider.PreviousAccessKey = tc.previous
+			e := external{cloudianService: cloudian.NewClient(admin.URL, "")}
+
+			if _, err := e.Delete(context.Background(), cr); err != nil {
+				t.Errorf("\n%s\ne.Delete(...): %v\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, api.requests); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want requests, +got requests:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAddTemplateDetails(t *testing.T) {
+	type want struct {
+		requests []string
+		details  string
+	}
+
+	cases := map[string]struct {
+		reason   string
+		template string
+		want     want
+	}{
+		"WithoutUser": {
+			reason:   "The user should not be fetched for templates that do not need it.",
+			template: "{{ .UserID }}:{{ .AccessKey }}",
+			want:     want{details: "bar:key"},
+		},
+		"CanonicalID": {
+			reason:   "The user should be fetched for templates using its canonical ID.",
+			template: "{{ .CanonicalID }}:{{ .AccessKey }}",
+			want:     want{requests: []string{"GET /user"}, details: "123:key"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				json.NewEncoder(w).Encode(map[string]any{"groupId": "foo", "userId": "bar", "canonicalUserId": "123"})
+			}))
+			defer admin.Close()
+
+			cr := &v1alpha1.AccessKey{Spec: v1alpha1.AccessKeySpec{ForProvider: v1alpha1.AccessKeyParameters{
+				GroupID:             "foo",
+				UserID:              "bar",
+				ConnectionTemplates: map[string]string{"template": tc.template},
+			}}}
+			e := external{cloudianService: cloudian.NewClient(admin.URL, "")}
+			details := managed.ConnectionDetails{}
+
+			if err := e.addTemplateDetails(context.Background(), cr, &cloudian.SecurityInfo{AccessKey: "key"}, details); err != nil {
+				t.Fatalf("\n%s\ne.addTemplateDetails(...): %v\n", tc.reason, err)
+			}
+			got := want{requests: requests, details: string(details["template"])}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.addTemplateDetails(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRotation(t *testing.T) {
+	created := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	rotation := &v1alpha1.AccessKeyRotation{
+		MaxAge:        metav1.Duration{Duration: 30 * 24 * time.Hour},
+		OverlapPeriod: metav1.Duration{Duration: 24 * time.Hour},
+		GracePeriod:   metav1.Duration{Duration: time.Hour},
+	}
+
+	type want struct {
+		due       bool
+		published bool
+		expired   bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		params v1alpha1.AccessKeyParameters
+		obs    v1alpha1.AccessKeyObservation
+		now    time.Time
+		want   want
+	}{
+		"NoRotation": {
+			reason: "Access keys without a rotation policy should never be rotated.",
+			params: v1alpha1.AccessKeyParameters{Active: true},
+			obs:    v1alpha1.AccessKeyObservation{CreatedAt: &created},
+			now:    created.AddDate(1, 0, 0),
+		},
+		"NotYetDue": {
+			reason: "Access keys younger than the max age should not be rotated.",
+			params: v1alpha1.AccessKeyParameters{Active: true, Rotation: rotation},
+			obs:    v1alpha1.AccessKeyObservation{CreatedAt: &created},
+			now:    created.AddDate(0, 0, 29),
+		},
+		"Due": {
+			reason: "Access keys that have reached the max age should be rotated.",
+			params: v1alpha1.AccessKeyParameters{Active: true, Rotation: rotation},
+			obs:    v1alpha1.AccessKeyObservation{CreatedAt: &created},
+			now:    created.AddDate(0, 0, 30),
+			want:   want{due: true},
+		},
+		"Inactive": {
+			reason: "Inactive access keys should not be rotated.",
+			params: v1alpha1.AccessKeyParameters{Rotation: rotation},
+			obs:    v1alpha1.AccessKeyObservation{CreatedAt: &created},
+			now:    created.AddDate(0, 0, 30),
+		},
+		"Overlap": {
+			reason: "The previous access key should be published during the overlap period.",
+			params: v1alpha1.AccessKeyParameters{Active: true, Rotation: rotation},
+			obs:    v1alpha1.AccessKeyObservation{CreatedAt: &created, PreviousAccessKey: "old"},
+			now:    created.Add(time.Hour),
+			want:   want{published: true},
+		},
+		"Grace": {
+			reason: "The previous access key should be kept, but not published, during the grace period.",
+			params: v1alpha1.AccessKeyParameters{Active: true, Rotation: rotation},
+			obs:    v1alpha1.AccessKeyObservation{CreatedAt: &created, PreviousAccessKey: "old"},
+			now:    created.Add(24*time.Hour + time.Minute),
+		},
+		"Expired": {
+			reason: "The previous access key should be deleted after the grace period.",
+			params: v1alpha1.AccessKeyParameters{Active: true, Rotation: rotation},
+			obs:    v1alpha1.AccessKeyObservation{CreatedAt: &created, PreviousAccessKey: "old"},
+			now:    created.Add(25 * time.Hour),
+			want:   want{expired: true},
+		},
+		"RotationDisabled": {
+			reason: "The previous access key should be deleted if rotation is disabled.",
+			params: v1alpha1.AccessKeyParameters{Active: true},
+			obs:    v1alpha1.AccessKeyObservation{CreatedAt: &created, PreviousAccessKey: "old"},
+			now:    created.Add(time.Hour),
+			want:   want{expired: true},
+		},
+		"PreviousNotDeleted": {
+			reason: "Access keys should not be rotated while the previous access key exists.",
+			params: v1alpha1.AccessKeyParameters{Active: true, Rotation: rotation},
+			obs:    v1alpha1.AccessKeyObservation{CreatedAt: &created, PreviousAccessKey: "old"},
+			now:    created.AddDate(0, 0, 30),
+			want:   want{expired: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := want{
+				due:       rotationDue(tc.params, tc.obs, tc.now),
+				published: previousPublished(tc.params, tc.obs, tc.now),
+				expired:   previousExpired(tc.params, tc.obs, tc.now),
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nrotation: -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
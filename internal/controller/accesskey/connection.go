@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accesskey
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/statnett/provider-cloudian/apis/user/v1alpha1"
+	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+const defaultProfile = "default"
+
+// connectionDetails publishes credentials in the connection formats of an
+// AccessKey, along with the S3 endpoint of its ProviderConfig.
+func connectionDetails(creds *cloudian.SecurityInfo, params v1alpha1.AccessKeyParameters, s3 *apisv1alpha1.S3Config) managed.ConnectionDetails {
+	var endpoint, region string
+	if s3 != nil {
+		endpoint, region = s3.Endpoint, s3.Region
+	}
+	profile := params.Profile
+	if profile == "" {
+		profile = defaultProfile
+	}
+
+	details := managed.ConnectionDetails{
+		"accessKey": []byte(creds.AccessKey),
+		"secretKey": []byte(creds.SecretKey),
+		"config.toml": []byte(fmt.Sprintf(
+			`[default]
+aws_access_key_id = %s
+aws_secret_access_key = %s`,
+			creds.AccessKey,
+			creds.SecretKey,
+		)),
+	}
+	if endpoint != "" {
+		details["endpoint"] = []byte(endpoint)
+	}
+	if region != "" {
+		details["region"] = []byte(region)
+	}
+
+	for _, format := range params.ConnectionFormats {
+		switch format {
+		case v1alpha1.ConnectionFormatEnv:
+			details["AWS_ACCESS_KEY_ID"] = []byte(creds.AccessKey)
+			details["AWS_SECRET_ACCESS_KEY"] = []byte(creds.SecretKey)
+			if endpoint != "" {
+				details["AWS_ENDPOINT_URL"] = []byte(endpoint)
+			}
+			if region != "" {
+				details["AWS_REGION"] = []byte(region)
+			}
+		case v1alpha1.ConnectionFormatAWSCredentials:
+			details["credentials"] = []byte(awsCredentials(creds, profile))
+		case v1alpha1.ConnectionFormatS3cmd:
+			details[".s3cfg"] = []byte(s3cmdConfig(creds, endpoint, region))
+		case v1alpha1.ConnectionFormatRclone:
+			details["rclone.conf"] = []byte(rcloneConfig(creds, profile, endpoint, region))
+		}
+	}
+
+	return details
+}
+
+func awsCredentials(creds *cloudian.SecurityInfo, profile string) string {
+	return fmt.Sprintf(`[%s]
+aws_access_key_id = %s
+aws_secret_access_key = %s
+`,
+		profile,
+		creds.AccessKey,
+		creds.SecretKey,
+	)
+}
+
+func s3cmdConfig(creds *cloudian.SecurityInfo, endpoint, region string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `[default]
+access_key = %s
+secret_key = %s
+`,
+		creds.AccessKey,
+		creds.SecretKey,
+	)
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		// Path style requests, which do not depend on wildcard DNS records.
+		fmt.Fprintf(&b, `host_base = %s
+host_bucket = %s
+use_https = %s
+`,
+			u.Host,
+			u.Host,
+			pythonBool(u.Scheme == "https"),
+		)
+	}
+	if region != "" {
+		fmt.Fprintf(&b, "bucket_location = %s\n", region)
+	}
+	return b.String()
+}
+
+func rcloneConfig(creds *cloudian.SecurityInfo, remote, endpoint, region string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `[%s]
+type = s3
+provider = Other
+access_key_id = %s
+secret_access_key = %s
+`,
+		remote,
+		creds.AccessKey,
+		creds.SecretKey,
+	)
+	if endpoint != "" {
+		fmt.Fprintf(&b, "endpoint = %s\n", endpoint)
+	}
+	if region != "" {
+		fmt.Fprintf(&b, "region = %s\n", region)
+	}
+	return b.String()
+}
+
+// pythonBool formats a boolean for the Python based s3cmd.
+func pythonBool(b bool) string {
+	if b {
+		return "True"
+	}
+	return "False"
+}
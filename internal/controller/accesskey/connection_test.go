@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accesskey
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/statnett/provider-cloudian/apis/user/v1alpha1"
+	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+func TestConnectionDetails(t *testing.T) {
+	creds := &cloudian.SecurityInfo{AccessKey: "AKID", SecretKey: "secret"}
+	legacy := "[default]\naws_access_key_id = AKID\naws_secret_access_key = secret"
+	s3 := &apisv1alpha1.S3Config{Endpoint: "https://s3.example.com", Region: "region1"}
+
+	cases := map[string]struct {
+		reason string
+		params v1alpha1.AccessKeyParameters
+		s3     *apisv1alpha1.S3Config
+		want   managed.ConnectionDetails
+	}{
+		"NoFormats": {
+			reason: "The access key, secret key and legacy config.toml should always be published.",
+			want: managed.ConnectionDetails{
+				"accessKey":   []byte("AKID"),
+				"secretKey":   []byte("secret"),
+				"config.toml": []byte(legacy),
+			},
+		},
+		"AllFormats": {
+			reason: "Every requested format should be published, including the S3 endpoint and region.",
+			params: v1alpha1.AccessKeyParameters{
+				Profile: "cloudian",
+				ConnectionFormats: []v1alpha1.ConnectionFormat{
+					v1alpha1.ConnectionFormatEnv,
+					v1alpha1.ConnectionFormatAWSCredentials,
+					v1alpha1.ConnectionFormatS3cmd,
+					v1alpha1.ConnectionFormatRclone,
+				},
+			},
+			s3: s3,
+			want: managed.ConnectionDetails{
+				"accessKey":             []byte("AKID"),
+				"secretKey":             []byte("secret"),
+				"config.toml":           []byte(legacy),
+				"endpoint":              []byte("https://s3.example.com"),
+				"region":                []byte("region1"),
+				"AWS_ACCESS_KEY_ID":     []byte("AKID"),
+				"AWS_SECRET_ACCESS_KEY": []byte("secret"),
+				"AWS_ENDPOINT_URL":      []byte("https://s3.example.com"),
+				"AWS_REGION":            []byte("region1"),
+				"credentials":           []byte("[cloudian]\naws_access_key_id = AKID\naws_secret_access_key = secret\n"),
+				".s3cfg": []byte("[default]\naccess_key = AKID\nsecret_key = secret\n" +
+					"host_base = s3.example.com\nhost_bucket = s3.example.com\nuse_https = True\nbucket_location = region1\n"),
+				"rclone.conf": []byte("[cloudian]\ntype = s3\nprovider = Other\naccess_key_id = AKID\nsecret_access_key = secret\n" +
+					"endpoint = https://s3.example.com\nregion = region1\n"),
+			},
+		},
+		"DefaultProfile": {
+			reason: "The default profile should be used when none is set.",
+			params: v1alpha1.AccessKeyParameters{
+				ConnectionFormats: []v1alpha1.ConnectionFormat{v1alpha1.ConnectionFormatAWSCredentials},
+			},
+			want: managed.ConnectionDetails{
+				"accessKey":   []byte("AKID"),
+				"secretKey":   []byte("secret"),
+				"config.toml": []byte(legacy),
+				"credentials": []byte("[default]\naws_access_key_id = AKID\naws_secret_access_key = secret\n"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := connectionDetails(creds, tc.params, tc.s3)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nconnectionDetails(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/statnett/provider-cloudian/apis/s3/v1alpha1"
+	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/controller/connector"
+	"github.com/statnett/provider-cloudian/internal/controller/s3client"
+	"github.com/statnett/provider-cloudian/internal/features"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+	"github.com/statnett/provider-cloudian/internal/sdk/s3"
+)
+
+const (
+	errNotBucket    = "managed resource is not a Bucket custom resource"
+	errNewS3Client  = "cannot create S3 client"
+	errGetBucket    = "cannot get bucket"
+	errCreateBucket = "cannot create bucket"
+	errDeleteBucket = "cannot delete bucket"
+)
+
+// Setup adds a controller that reconciles Bucket managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.BucketGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	conn := connector.New(mgr.GetClient(), newExternal)
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(conn),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...),
+	}
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.BucketGroupVersionKind), opts...)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.Bucket{}).
+		Complete(ratelimiter.NewReconciler(name, conn.Reconciler(r, o.PollInterval), o.GlobalRateLimiter))
+}
+
+func newExternal(svc *cloudian.Client, pc *apisv1alpha1.ProviderConfig) managed.ExternalClient {
+	return &external{cloudianService: svc, s3: pc.Spec.S3}
+}
+
+// An ExternalClient observes, then either creates or deletes a bucket. The S3
+// client is built per request, as it acts as the owner of the bucket.
+type external struct {
+	cloudianService *cloudian.Client
+	s3              *apisv1alpha1.S3Config
+}
+
+func (c *external) s3Client(ctx context.Context, cr *v1alpha1.Bucket) (*s3.Client, error) {
+	client, err := s3client.New(ctx, c.cloudianService, c.s3, cr.Spec.ForProvider.S3Credentials)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewS3Client)
+	}
+	return client, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Bucket)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotBucket)
+	}
+
+	client, err := c.s3Client(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	observed, err := client.GetBucket(ctx, meta.GetExternalName(cr))
+	if errors.Is(err, s3.ErrNotFound) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetBucket)
+	}
+
+	cr.Status.AtProvider = v1alpha1.BucketObservation{
+		Region:            observed.Region,
+		ObjectLockEnabled: observed.ObjectLockEnabled,
+	}
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// All parameters of a bucket are immutable, so an existing bucket is
+		// always up to date.
+		ResourceUpToDate: true,
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Bucket)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotBucket)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	client, err := c.s3Client(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	region := cr.Spec.ForProvider.Region
+	if region == "" && c.s3 != nil {
+		region = c.s3.Region
+	}
+	bucket := s3.Bucket{
+		Name:              meta.GetExternalName(cr),
+		Region:            region,
+		StoragePolicyID:   cr.Spec.ForProvider.StoragePolicyID,
+		ObjectLockEnabled: cr.Spec.ForProvider.ObjectLockEnabled,
+	}
+	if err := client.CreateBucket(ctx, bucket); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateBucket)
+	}
+
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	// Buckets have no mutable parameters.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*v1alpha1.Bucket)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotBucket)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	client, err := c.s3Client(ctx, cr)
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
+	if err := client.DeleteBucket(ctx, meta.GetExternalName(cr)); err != nil && !errors.Is(err, s3.ErrNotFound) {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteBucket)
+	}
+
+	return managed.ExternalDelete{}, nil
+}
+
+func (c *external) Disconnect(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/statnett/provider-cloudian/apis/s3/v1alpha1"
+	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+	"github.com/statnett/provider-cloudian/internal/sdk/s3/s3test"
+)
+
+func TestLifecycle(t *testing.T) {
+	admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"accessKey": "key", "secretKey": "secret", "active": true})
+	}))
+	defer admin.Close()
+	s3Server := s3test.NewServer()
+	defer s3Server.Close()
+
+	e := &external{
+		cloudianService: cloudian.NewClient(admin.URL, ""),
+		s3:              &apisv1alpha1.S3Config{Endpoint: s3Server.URL, Region: "region1"},
+	}
+	cr := &v1alpha1.Bucket{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Spec: v1alpha1.BucketSpec{ForProvider: v1alpha1.BucketParameters{
+			S3Credentials:     v1alpha1.S3Credentials{AccessKeyID: "key"},
+			StoragePolicyID:   "policy1",
+			ObjectLockEnabled: true,
+		}},
+	}
+	meta.SetExternalName(cr, "foo")
+	ctx := context.Background()
+
+	obs, err := e.Observe(ctx, cr)
+	if err != nil {
+		t.Fatalf("Observe() error = %v", err)
+	}
+	if diff := cmp.Diff(managed.ExternalObservation{}, obs); diff != "" {
+		t.Errorf("Observe() before Create mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := e.Create(ctx, cr); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	got, _ := s3Server.Bucket("foo")
+	want := s3test.Bucket{Region: "region1", StoragePolicyID: "policy1", ObjectLockEnabled: true}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Create() bucket mismatch (-want +got):\n%s", diff)
+	}
+
+	obs, err = e.Observe(ctx, cr)
+	if err != nil {
+		t.Fatalf("Observe() error = %v", err)
+	}
+	if !obs.ResourceExists || !obs.ResourceUpToDate {
+		t.Errorf("Observe() after Create = %+v, want existing and up to date", obs)
+	}
+	wantObs := v1alpha1.BucketObservation{Region: "region1", ObjectLockEnabled: true}
+	if diff := cmp.Diff(wantObs, cr.Status.AtProvider); diff != "" {
+		t.Errorf("Observe() status mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := e.Delete(ctx, cr); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, ok := s3Server.Bucket("foo"); ok {
+		t.Error("Delete() did not delete the bucket")
+	}
+	if _, err := e.Delete(ctx, cr); err != nil {
+		t.Errorf("Delete() of deleted bucket error = %v", err)
+	}
+}
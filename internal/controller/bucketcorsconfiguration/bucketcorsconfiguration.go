@@ -0,0 +1,232 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucketcorsconfiguration
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/statnett/provider-cloudian/apis/s3/v1alpha1"
+	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/controller/connector"
+	"github.com/statnett/provider-cloudian/internal/controller/s3client"
+	"github.com/statnett/provider-cloudian/internal/features"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+	"github.com/statnett/provider-cloudian/internal/sdk/s3"
+)
+
+const (
+	errNotBucketCORSConfiguration = "managed resource is not a BucketCORSConfiguration custom resource"
+	errNewS3Client                = "cannot create S3 client"
+	errGetCORS                    = "cannot get bucket CORS configuration"
+	errPutCORS                    = "cannot put bucket CORS configuration"
+	errDeleteCORS                 = "cannot delete bucket CORS configuration"
+)
+
+// Setup adds a controller that reconciles BucketCORSConfiguration managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.BucketCORSConfigurationGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	conn := connector.New(mgr.GetClient(), newExternal)
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(conn),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...),
+	}
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.BucketCORSConfigurationGroupVersionKind), opts...)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.BucketCORSConfiguration{}).
+		Complete(ratelimiter.NewReconciler(name, conn.Reconciler(r, o.PollInterval), o.GlobalRateLimiter))
+}
+
+func newExternal(svc *cloudian.Client, pc *apisv1alpha1.ProviderConfig) managed.ExternalClient {
+	return &external{cloudianService: svc, s3: pc.Spec.S3}
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes the
+// CORS rules of a bucket.
+type external struct {
+	cloudianService *cloudian.Client
+	s3              *apisv1alpha1.S3Config
+}
+
+func (c *external) s3Client(ctx context.Context, cr *v1alpha1.BucketCORSConfiguration) (*s3.Client, error) {
+	client, err := s3client.New(ctx, c.cloudianService, c.s3, cr.Spec.ForProvider.S3Credentials)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewS3Client)
+	}
+	return client, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.BucketCORSConfiguration)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotBucketCORSConfiguration)
+	}
+
+	client, err := c.s3Client(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	observed, err := client.GetBucketCORS(ctx, cr.Spec.ForProvider.Bucket)
+	if errors.Is(err, s3.ErrNotFound) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetCORS)
+	}
+
+	cr.Status.AtProvider.Rules = fromS3Rules(observed)
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Return false when the external resource exists, but it not up to date
+		// with the desired managed resource state. This lets the managed
+		// resource reconciler know that it needs to call Update.
+		ResourceUpToDate: isUpToDate(cr.Spec.ForProvider.Rules, observed),
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.BucketCORSConfiguration)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotBucketCORSConfiguration)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	if err := c.put(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.BucketCORSConfiguration)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotBucketCORSConfiguration)
+	}
+
+	if err := c.put(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) put(ctx context.Context, cr *v1alpha1.BucketCORSConfiguration) error {
+	client, err := c.s3Client(ctx, cr)
+	if err != nil {
+		return err
+	}
+	if err := client.PutBucketCORS(ctx, cr.Spec.ForProvider.Bucket, toS3Rules(cr.Spec.ForProvider.Rules)); err != nil {
+		return errors.Wrap(err, errPutCORS)
+	}
+	return nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*v1alpha1.BucketCORSConfiguration)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotBucketCORSConfiguration)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	client, err := c.s3Client(ctx, cr)
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
+	if err := client.DeleteBucketCORS(ctx, cr.Spec.ForProvider.Bucket); err != nil && !errors.Is(err, s3.ErrNotFound) {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteCORS)
+	}
+
+	return managed.ExternalDelete{}, nil
+}
+
+func (c *external) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// isUpToDate returns whether the CORS rules of a bucket are the desired rules.
+// The order of rules matters, as the first rule matching a request applies.
+func isUpToDate(desired []v1alpha1.CORSRule, observed []s3.CORSRule) bool {
+	return cmp.Equal(toS3Rules(desired), observed, cmpopts.EquateEmpty())
+}
+
+func toS3Rules(rules []v1alpha1.CORSRule) []s3.CORSRule {
+	out := make([]s3.CORSRule, 0, len(rules))
+	for _, r := range rules {
+		out = append(out, s3.CORSRule(r))
+	}
+	return out
+}
+
+func fromS3Rules(rules []s3.CORSRule) []v1alpha1.CORSRule {
+	out := make([]v1alpha1.CORSRule, 0, len(rules))
+	for _, r := range rules {
+		out = append(out, v1alpha1.CORSRule(r))
+	}
+	return out
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucketcorsconfiguration
+
+import (
+	"testing"
+
+	"github.com/statnett/provider-cloudian/apis/s3/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/s3"
+)
+
+func TestIsUpToDate(t *testing.T) {
+	desired := []v1alpha1.CORSRule{
+		{ID: "web", AllowedMethods: []string{"GET"}, AllowedOrigins: []string{"https://example.com"}},
+		{ID: "all", AllowedMethods: []string{"HEAD"}, AllowedOrigins: []string{"*"}, AllowedHeaders: []string{}},
+	}
+
+	cases := map[string]struct {
+		observed []s3.CORSRule
+		want     bool
+	}{
+		"SameRules": {
+			observed: []s3.CORSRule{
+				{ID: "web", AllowedMethods: []string{"GET"}, AllowedOrigins: []string{"https://example.com"}},
+				{ID: "all", AllowedMethods: []string{"HEAD"}, AllowedOrigins: []string{"*"}},
+			},
+			want: true,
+		},
+		"OtherOrder": {
+			observed: []s3.CORSRule{
+				{ID: "all", AllowedMethods: []string{"HEAD"}, AllowedOrigins: []string{"*"}},
+				{ID: "web", AllowedMethods: []string{"GET"}, AllowedOrigins: []string{"https://example.com"}},
+			},
+			want: false,
+		},
+		"DifferentOrigin": {
+			observed: []s3.CORSRule{
+				{ID: "web", AllowedMethods: []string{"GET"}, AllowedOrigins: []string{"https://example.org"}},
+				{ID: "all", AllowedMethods: []string{"HEAD"}, AllowedOrigins: []string{"*"}},
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := isUpToDate(desired, tc.observed); got != tc.want {
+				t.Errorf("isUpToDate() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucketlifecycleconfiguration
+
+import (
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/statnett/provider-cloudian/apis/s3/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/s3"
+)
+
+func TestIsUpToDate(t *testing.T) {
+	desired := []v1alpha1.LifecycleRule{
+		{ID: "logs", Status: "Enabled", Prefix: "logs/", ExpirationDays: ptr.To[int32](30)},
+		{ID: "uploads", Status: "Disabled", AbortIncompleteMultipartUploadDays: ptr.To[int32](1)},
+	}
+
+	cases := map[string]struct {
+		observed []s3.LifecycleRule
+		want     bool
+	}{
+		"SameRulesInOtherOrder": {
+			observed: []s3.LifecycleRule{
+				{ID: "uploads", AbortIncompleteMultipartUploadDays: ptr.To[int32](1)},
+				{ID: "logs", Enabled: true, Prefix: "logs/", ExpirationDays: ptr.To[int32](30)},
+			},
+			want: true,
+		},
+		"DifferentExpiration": {
+			observed: []s3.LifecycleRule{
+				{ID: "logs", Enabled: true, Prefix: "logs/", ExpirationDays: ptr.To[int32](60)},
+				{ID: "uploads", AbortIncompleteMultipartUploadDays: ptr.To[int32](1)},
+			},
+			want: false,
+		},
+		"DifferentStatus": {
+			observed: []s3.LifecycleRule{
+				{ID: "logs", Enabled: true, Prefix: "logs/", ExpirationDays: ptr.To[int32](30)},
+				{ID: "uploads", Enabled: true, AbortIncompleteMultipartUploadDays: ptr.To[int32](1)},
+			},
+			want: false,
+		},
+		"MissingRule": {
+			observed: []s3.LifecycleRule{
+				{ID: "logs", Enabled: true, Prefix: "logs/", ExpirationDays: ptr.To[int32](30)},
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := isUpToDate(desired, tc.observed); got != tc.want {
+				t.Errorf("isUpToDate() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStatusRoundTrip(t *testing.T) {
+	rules := []v1alpha1.LifecycleRule{{ID: "a", Status: "Enabled"}, {ID: "b", Status: "Disabled"}}
+	if !isUpToDate(fromS3Rules(toS3Rules(rules)), toS3Rules(rules)) {
+		t.Error("fromS3Rules(toS3Rules()) did not round trip")
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucketpolicy
+
+import (
+	"testing"
+)
+
+func TestPolicyEqual(t *testing.T) {
+	cases := map[string]struct {
+		desired  string
+		observed string
+		want     bool
+		wantErr  bool
+	}{
+		"Identical": {
+			desired:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject"}]}`,
+			observed: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject"}]}`,
+			want:     true,
+		},
+		"FormattingAndKeyOrder": {
+			desired: `{
+				"Version": "2012-10-17",
+				"Statement": [{"Effect": "Allow", "Action": "s3:GetObject"}]
+			}`,
+			observed: `{"Statement":[{"Action":"s3:GetObject","Effect":"Allow"}],"Version":"2012-10-17"}`,
+			want:     true,
+		},
+		"DifferentAction": {
+			desired:  `{"Statement":[{"Effect":"Allow","Action":"s3:GetObject"}]}`,
+			observed: `{"Statement":[{"Effect":"Allow","Action":"s3:PutObject"}]}`,
+			want:     false,
+		},
+		"StatementOrder": {
+			desired:  `{"Statement":[{"Sid":"a"},{"Sid":"b"}]}`,
+			observed: `{"Statement":[{"Sid":"b"},{"Sid":"a"}]}`,
+			want:     false,
+		},
+		"InvalidDesired": {
+			desired:  `{"Statement":`,
+			observed: `{}`,
+			wantErr:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := policyEqual(tc.desired, tc.observed)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("policyEqual() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("policyEqual() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucketversioning
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/statnett/provider-cloudian/apis/s3/v1alpha1"
+	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+	"github.com/statnett/provider-cloudian/internal/sdk/s3"
+	"github.com/statnett/provider-cloudian/internal/sdk/s3/s3test"
+)
+
+func TestObserve(t *testing.T) {
+	admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"accessKey": "key", "secretKey": "secret", "active": true})
+	}))
+	defer admin.Close()
+	s3Server := s3test.NewServer()
+	defer s3Server.Close()
+
+	s3Server.SetBucket("unversioned", s3test.Bucket{})
+	s3Server.SetBucket("enabled", s3test.Bucket{Versioning: s3.VersioningEnabled})
+	s3Server.SetBucket("suspended", s3test.Bucket{Versioning: s3.VersioningSuspended})
+
+	e := &external{
+		cloudianService: cloudian.NewClient(admin.URL, ""),
+		s3:              &apisv1alpha1.S3Config{Endpoint: s3Server.URL},
+	}
+
+	cases := map[string]struct {
+		bucket  string
+		status  string
+		deleted bool
+		want    managed.ExternalObservation
+	}{
+		"MissingBucket": {
+			bucket: "missing",
+			status: s3.VersioningEnabled,
+			want:   managed.ExternalObservation{},
+		},
+		"Unversioned": {
+			bucket: "unversioned",
+			status: s3.VersioningEnabled,
+			want:   managed.ExternalObservation{},
+		},
+		"UpToDate": {
+			bucket: "enabled",
+			status: s3.VersioningEnabled,
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+		},
+		"NeedsUpdate": {
+			bucket: "suspended",
+			status: s3.VersioningEnabled,
+			want:   managed.ExternalObservation{ResourceExists: true, ConnectionDetails: managed.ConnectionDetails{}},
+		},
+		"SuspendedWhenDeleted": {
+			bucket:  "suspended",
+			status:  s3.VersioningEnabled,
+			deleted: true,
+			want:    managed.ExternalObservation{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.BucketVersioning{
+				Spec: v1alpha1.BucketVersioningSpec{ForProvider: v1alpha1.BucketVersioningParameters{
+					BucketTarget:  v1alpha1.BucketTarget{Bucket: tc.bucket},
+					S3Credentials: v1alpha1.S3Credentials{AccessKeyID: "key"},
+					Status:        tc.status,
+				}},
+			}
+			if tc.deleted {
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+			}
+
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("Observe() error = %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Observe() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
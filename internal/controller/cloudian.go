@@ -21,9 +21,17 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/statnett/provider-cloudian/internal/controller/accesskey"
+	"github.com/statnett/provider-cloudian/internal/controller/bucket"
+	"github.com/statnett/provider-cloudian/internal/controller/bucketcorsconfiguration"
+	"github.com/statnett/provider-cloudian/internal/controller/bucketlifecycleconfiguration"
+	"github.com/statnett/provider-cloudian/internal/controller/bucketpolicy"
+	"github.com/statnett/provider-cloudian/internal/controller/bucketversioning"
 	"github.com/statnett/provider-cloudian/internal/controller/config"
+	"github.com/statnett/provider-cloudian/internal/controller/connector"
 	"github.com/statnett/provider-cloudian/internal/controller/group"
 	"github.com/statnett/provider-cloudian/internal/controller/groupqualityofservicelimits"
+	"github.com/statnett/provider-cloudian/internal/controller/ratingplan"
+	"github.com/statnett/provider-cloudian/internal/controller/storagepolicy"
 	"github.com/statnett/provider-cloudian/internal/controller/user"
 	"github.com/statnett/provider-cloudian/internal/controller/userqualityofservicelimits"
 )
@@ -33,9 +41,16 @@ import (
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	for _, setup := range []func(ctrl.Manager, controller.Options) error{
 		accesskey.Setup,
-		config.Setup,
+		bucket.Setup,
+		bucketcorsconfiguration.Setup,
+		bucketlifecycleconfiguration.Setup,
+		bucketpolicy.Setup,
+		bucketversioning.Setup,
+		setupConfig,
 		group.Setup,
 		groupqualityofservicelimits.Setup,
+		ratingplan.Setup,
+		storagepolicy.Setup,
 		user.Setup,
 		userqualityofservicelimits.Setup,
 	} {
@@ -45,3 +60,9 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 	}
 	return nil
 }
+
+// setupConfig adds the ProviderConfig controller, which checks endpoints with
+// the clients shared by the controllers of managed resources.
+func setupConfig(mgr ctrl.Manager, o controller.Options) error {
+	return config.Setup(mgr, o, connector.New(mgr.GetClient(), nil).Client)
+}
@@ -17,19 +17,41 @@ limitations under the License.
 package config
 
 import (
+	"context"
+	"time"
+
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/providerconfig"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+const (
+	errGetPC        = "cannot get ProviderConfig"
+	errUpdateStatus = "cannot update ProviderConfig status"
 )
 
+// A ClientFn returns the Cloudian client shared by the managed resources of a
+// ProviderConfig.
+type ClientFn func(ctx context.Context, pc *v1alpha1.ProviderConfig) (*cloudian.Client, error)
+
 // Setup adds a controller that reconciles ProviderConfigs by accounting for
-// their current usage.
-func Setup(mgr ctrl.Manager, o controller.Options) error {
+// their current usage, validating their TLS configuration and checking the
+// health of their endpoints every poll interval. Endpoints are checked with
+// the client returned by clientFn, so that the status reports the endpoint
+// health and active endpoint of the client managed resources use.
+func Setup(mgr ctrl.Manager, o controller.Options, clientFn ClientFn) error {
 	name := providerconfig.ControllerName(v1alpha1.ProviderConfigGroupKind)
 
 	of := resource.ProviderConfigKinds{
@@ -46,5 +68,89 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.ProviderConfig{}).
 		Watches(&v1alpha1.ProviderConfigUsage{}, &resource.EnqueueRequestForProviderConfig{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, &statusReconciler{Reconciler: r, kube: mgr.GetClient(), client: clientFn, pollInterval: o.PollInterval}, o.GlobalRateLimiter))
+}
+
+// A statusReconciler reports the validity of a ProviderConfig's settings as
+// status conditions, and the health of its endpoints, after the wrapped
+// Reconciler has accounted for its usage.
+type statusReconciler struct {
+	reconcile.Reconciler
+	kube         client.Client
+	client       ClientFn
+	pollInterval time.Duration
+}
+
+func (r *statusReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	result, err := r.Reconciler.Reconcile(ctx, req)
+	if err != nil {
+		return result, err
+	}
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil {
+		return result, errors.Wrap(resource.IgnoreNotFound(err), errGetPC)
+	}
+	if meta.WasDeleted(pc) {
+		return result, nil
+	}
+
+	// Check the health of the endpoints again after the poll interval.
+	if result.RequeueAfter == 0 || result.RequeueAfter > r.pollInterval {
+		result.RequeueAfter = r.pollInterval
+	}
+
+	status := pc.Status.DeepCopy()
+
+	cond := v1alpha1.TLSValid()
+	if _, err := TLSConfig(ctx, r.kube, pc); err != nil {
+		cond = v1alpha1.TLSInvalid(err)
+	}
+	if !pc.GetCondition(cond.Type).Equal(cond) {
+		status.SetConditions(cond)
+	}
+
+	// Endpoints are only checked when a client can be built, otherwise the
+	// Synced condition of managed resources reports why. The checks update the
+	// endpoint health of the shared client, which then tries unhealthy
+	// endpoints again as soon as they recover.
+	if svc, err := r.client(ctx, pc); err == nil {
+		status.Endpoints = endpointStatuses(svc.CheckEndpoints(ctx), pc.Status.Endpoints, metav1.Now())
+		status.ActiveEndpoint = ""
+		if pc.Spec.EndpointPolicy != string(cloudian.EndpointPolicyRoundRobin) {
+			status.ActiveEndpoint = svc.ActiveEndpoint()
+		}
+	}
+
+	if equality.Semantic.DeepEqual(*status, pc.Status) {
+		return result, nil
+	}
+
+	pc.Status = *status
+	return result, errors.Wrap(r.kube.Status().Update(ctx, pc), errUpdateStatus)
+}
+
+// endpointStatuses converts the observed health of endpoints to their status,
+// keeping the last transition time of endpoints whose health is unchanged so
+// that the status is only updated when their health changes.
+func endpointStatuses(observed []cloudian.EndpointStatus, previous []v1alpha1.EndpointStatus, now metav1.Time) []v1alpha1.EndpointStatus {
+	transitions := make(map[string]v1alpha1.EndpointStatus, len(previous))
+	for _, p := range previous {
+		transitions[p.URL] = p
+	}
+
+	statuses := make([]v1alpha1.EndpointStatus, 0, len(observed))
+	for _, o := range observed {
+		s := v1alpha1.EndpointStatus{
+			URL:                o.URL,
+			Healthy:            o.Healthy,
+			Message:            o.LastError,
+			LastTransitionTime: now,
+		}
+		if p, ok := transitions[o.URL]; ok && p.Healthy == o.Healthy {
+			s.LastTransitionTime = p.LastTransitionTime
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses
 }
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+func TestEndpointStatuses(t *testing.T) {
+	then := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(then.Add(time.Hour))
+
+	cases := map[string]struct {
+		reason   string
+		observed []cloudian.EndpointStatus
+		previous []v1alpha1.EndpointStatus
+		want     []v1alpha1.EndpointStatus
+	}{
+		"NewEndpoints": {
+			reason:   "Endpoints without a previous status should transition now.",
+			observed: []cloudian.EndpointStatus{{URL: "https://a", Healthy: true}},
+			want:     []v1alpha1.EndpointStatus{{URL: "https://a", Healthy: true, LastTransitionTime: now}},
+		},
+		"UnchangedHealth": {
+			reason:   "Endpoints whose health is unchanged should keep their transition time.",
+			observed: []cloudian.EndpointStatus{{URL: "https://a", Healthy: true}},
+			previous: []v1alpha1.EndpointStatus{{URL: "https://a", Healthy: true, LastTransitionTime: then}},
+			want:     []v1alpha1.EndpointStatus{{URL: "https://a", Healthy: true, LastTransitionTime: then}},
+		},
+		"ChangedHealth": {
+			reason:   "Endpoints that became unhealthy should transition now and report the error.",
+			observed: []cloudian.EndpointStatus{{URL: "https://a", LastError: "connection refused"}, {URL: "https://b", Healthy: true}},
+			previous: []v1alpha1.EndpointStatus{{URL: "https://a", Healthy: true, LastTransitionTime: then}, {URL: "https://b", Healthy: true, LastTransitionTime: then}},
+			want: []v1alpha1.EndpointStatus{
+				{URL: "https://a", Message: "connection refused", LastTransitionTime: now},
+				{URL: "https://b", Healthy: true, LastTransitionTime: then},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := endpointStatuses(tc.observed, tc.previous, now)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nendpointStatuses(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestStatusReconcilerEndpoints(t *testing.T) {
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer up.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	spec := v1alpha1.ProviderConfigSpec{Endpoints: []string{down.URL, up.URL}}
+	svc := cloudian.NewClient("", "", cloudian.WithEndpoints(spec.Endpoints, cloudian.EndpointPolicyPriority))
+	var updated *v1alpha1.ProviderConfig
+	r := &statusReconciler{
+		Reconciler: reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+			return reconcile.Result{}, nil
+		}),
+		kube: &test.MockClient{
+			MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				obj.(*v1alpha1.ProviderConfig).Spec = spec
+				return nil
+			},
+			MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+				updated = obj.(*v1alpha1.ProviderConfig)
+				return nil
+			},
+		},
+		client: func(context.Context, *v1alpha1.ProviderConfig) (*cloudian.Client, error) {
+			return svc, nil
+		},
+		pollInterval: time.Minute,
+	}
+
+	if _, err := r.Reconcile(context.Background(), reconcile.Request{}); err != nil {
+		t.Fatalf("Reconcile(...): %v", err)
+	}
+	if updated == nil {
+		t.Fatal("Reconcile(...): status was not updated")
+	}
+	if updated.Status.ActiveEndpoint != up.URL {
+		t.Errorf("Reconcile(...): active endpoint = %q, want %q", updated.Status.ActiveEndpoint, up.URL)
+	}
+	healthy := map[string]bool{}
+	for _, e := range updated.Status.Endpoints {
+		healthy[e.URL] = e.Healthy
+	}
+	if diff := cmp.Diff(map[string]bool{down.URL: false, up.URL: true}, healthy); diff != "" {
+		t.Errorf("Reconcile(...): -want healthy, +got healthy:\n%s", diff)
+	}
+	if got := svc.ActiveEndpoint(); got != up.URL {
+		t.Errorf("Reconcile(...): active endpoint of the shared client = %q, want %q", got, up.URL)
+	}
+}
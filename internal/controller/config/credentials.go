@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/statnett/provider-cloudian/apis/v1alpha1"
+)
+
+const (
+	errNoCredentials       = "neither authHeader nor credentials are set"
+	errExtractAuthHeader   = "cannot extract authorization header"
+	errExtractCredentials  = "cannot extract credentials"
+	errGetCredentialSecret = "cannot get credentials Secret"
+	errParseCredentials    = "cannot parse credentials as JSON"
+	errNoUsername          = "credentials have no username"
+	errUsernamePassword    = "usernamePasswordSecretRef requires source Secret"
+)
+
+// usernamePassword is the JSON document expected by credential sources other
+// than a Secret with separate username and password keys.
+type usernamePassword struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// AuthHeader resolves the value of the Authorization header used in requests
+// to the Cloudian API from the credentials of a ProviderConfig.
+func AuthHeader(ctx context.Context, kube client.Client, pc *v1alpha1.ProviderConfig) (string, error) {
+	if creds := pc.Spec.Credentials; creds != nil {
+		up, err := userCredentials(ctx, kube, *creds)
+		if err != nil {
+			return "", err
+		}
+		if up.Username == "" {
+			return "", errors.New(errNoUsername)
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(up.Username+":"+up.Password)), nil
+	}
+
+	if ah := pc.Spec.AuthHeader; ah != nil {
+		header, err := resource.CommonCredentialExtractor(ctx, ah.Source, kube, ah.CommonCredentialSelectors)
+		return string(header), errors.Wrap(err, errExtractAuthHeader)
+	}
+
+	return "", errors.New(errNoCredentials)
+}
+
+func userCredentials(ctx context.Context, kube client.Client, creds v1alpha1.UserCredentials) (usernamePassword, error) {
+	if ref := creds.UsernamePasswordSecretRef; ref != nil {
+		if creds.Source != xpv1.CredentialsSourceSecret {
+			return usernamePassword{}, errors.New(errUsernamePassword)
+		}
+		secret := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+			return usernamePassword{}, errors.Wrap(err, errGetCredentialSecret)
+		}
+		return usernamePassword{
+			Username: string(secret.Data[ref.UsernameKey]),
+			Password: string(secret.Data[ref.PasswordKey]),
+		}, nil
+	}
+
+	raw, err := resource.CommonCredentialExtractor(ctx, creds.Source, kube, creds.CommonCredentialSelectors)
+	if err != nil {
+		return usernamePassword{}, errors.Wrap(err, errExtractCredentials)
+	}
+
+	var up usernamePassword
+	if err := json.Unmarshal(raw, &up); err != nil {
+		return usernamePassword{}, errors.Wrap(err, errParseCredentials)
+	}
+	return up, nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/statnett/provider-cloudian/apis/v1alpha1"
+)
+
+func TestAuthHeader(t *testing.T) {
+	secret := func(data map[string]string) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			s := obj.(*corev1.Secret)
+			s.Data = map[string][]byte{}
+			for k, v := range data {
+				s.Data[k] = []byte(v)
+			}
+			return nil
+		}
+	}
+	ref := xpv1.SecretReference{Name: "cloudian", Namespace: "crossplane-system"}
+
+	type want struct {
+		header string
+		err    bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		get    test.MockGetFn
+		spec   v1alpha1.ProviderConfigSpec
+		want   want
+	}{
+		"AuthHeader": {
+			reason: "The legacy authHeader should be used verbatim.",
+			get:    secret(map[string]string{"auth-header": "Basic c3lzYWRtaW46cGFzc3dvcmQ="}),
+			spec: v1alpha1.ProviderConfigSpec{AuthHeader: &v1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					SecretRef: &xpv1.SecretKeySelector{SecretReference: ref, Key: "auth-header"},
+				},
+			}},
+			want: want{header: "Basic c3lzYWRtaW46cGFzc3dvcmQ="},
+		},
+		"UsernamePasswordSecret": {
+			reason: "Separate username and password keys should be assembled into a basic auth header.",
+			get:    secret(map[string]string{"username": "sysadmin", "password": "password"}),
+			spec: v1alpha1.ProviderConfigSpec{Credentials: &v1alpha1.UserCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				UsernamePasswordSecretRef: &v1alpha1.UsernamePasswordSecretReference{
+					SecretReference: ref,
+					UsernameKey:     "username",
+					PasswordKey:     "password",
+				},
+			}},
+			want: want{header: "Basic c3lzYWRtaW46cGFzc3dvcmQ="},
+		},
+		"JSONSecret": {
+			reason: "A JSON document should be assembled into a basic auth header.",
+			get:    secret(map[string]string{"credentials": `{"username":"sysadmin","password":"password"}`}),
+			spec: v1alpha1.ProviderConfigSpec{Credentials: &v1alpha1.UserCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					SecretRef: &xpv1.SecretKeySelector{SecretReference: ref, Key: "credentials"},
+				},
+			}},
+			want: want{header: "Basic c3lzYWRtaW46cGFzc3dvcmQ="},
+		},
+		"InvalidJSON": {
+			reason: "A credentials document that is not JSON should return an error.",
+			get:    secret(map[string]string{"credentials": "sysadmin:password"}),
+			spec: v1alpha1.ProviderConfigSpec{Credentials: &v1alpha1.UserCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					SecretRef: &xpv1.SecretKeySelector{SecretReference: ref, Key: "credentials"},
+				},
+			}},
+			want: want{err: true},
+		},
+		"MissingUsername": {
+			reason: "Credentials without a username should return an error.",
+			get:    secret(map[string]string{"password": "password"}),
+			spec: v1alpha1.ProviderConfigSpec{Credentials: &v1alpha1.UserCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				UsernamePasswordSecretRef: &v1alpha1.UsernamePasswordSecretReference{
+					SecretReference: ref,
+					UsernameKey:     "username",
+					PasswordKey:     "password",
+				},
+			}},
+			want: want{err: true},
+		},
+		"UsernamePasswordWithoutSecretSource": {
+			reason: "A usernamePasswordSecretRef with a source other than Secret should return an error.",
+			get:    secret(map[string]string{"username": "sysadmin", "password": "password"}),
+			spec: v1alpha1.ProviderConfigSpec{Credentials: &v1alpha1.UserCredentials{
+				Source: xpv1.CredentialsSourceEnvironment,
+				UsernamePasswordSecretRef: &v1alpha1.UsernamePasswordSecretReference{
+					SecretReference: ref,
+					UsernameKey:     "username",
+					PasswordKey:     "password",
+				},
+			}},
+			want: want{err: true},
+		},
+		"NoCredentials": {
+			reason: "A ProviderConfig without credentials should return an error.",
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{MockGet: tc.get}
+			pc := &v1alpha1.ProviderConfig{Spec: tc.spec}
+
+			got, err := AuthHeader(context.Background(), kube, pc)
+			if (err != nil) != tc.want.err {
+				t.Errorf("\n%s\nAuthHeader(...): error = %v, wantErr %v", tc.reason, err, tc.want.err)
+			}
+			if diff := cmp.Diff(tc.want.header, got); diff != "" {
+				t.Errorf("\n%s\nAuthHeader(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+const (
+	errGetCABundleSecret    = "cannot get CA bundle Secret"
+	errGetCABundleConfigMap = "cannot get CA bundle ConfigMap"
+	errCABundleKeyFmt       = "CA bundle key %q not found in %s %s/%s"
+	errLoadTLSConfig        = "cannot load TLS configuration"
+)
+
+// TLSConfig resolves the TLS configuration of the Cloudian API of a
+// ProviderConfig, reading the CA bundle from a Secret or ConfigMap when
+// referenced.
+func TLSConfig(ctx context.Context, kube client.Client, pc *v1alpha1.ProviderConfig) (*tls.Config, error) {
+	return tlsConfig(ctx, kube, pc.Spec.TLS)
+}
+
+// S3TLSConfig resolves the TLS configuration of the S3 service of a
+// ProviderConfig, like TLSConfig.
+func S3TLSConfig(ctx context.Context, kube client.Client, pc *v1alpha1.ProviderConfig) (*tls.Config, error) {
+	if pc.Spec.S3 == nil {
+		return tlsConfig(ctx, kube, nil)
+	}
+	return tlsConfig(ctx, kube, pc.Spec.S3.TLS)
+}
+
+func tlsConfig(ctx context.Context, kube client.Client, spec *v1alpha1.TLSConfig) (*tls.Config, error) {
+	if spec == nil {
+		return cloudian.NewTLSConfig(nil, "", false)
+	}
+
+	caBundle, err := caBundle(ctx, kube, *spec)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := cloudian.NewTLSConfig(caBundle, spec.ServerName, spec.InsecureSkipVerify)
+	return config, errors.Wrap(err, errLoadTLSConfig)
+}
+
+func caBundle(ctx context.Context, kube client.Client, spec v1alpha1.TLSConfig) ([]byte, error) {
+	switch {
+	case spec.CABundleSecretRef != nil:
+		ref := spec.CABundleSecretRef
+		secret := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+			return nil, errors.Wrap(err, errGetCABundleSecret)
+		}
+		data, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, errors.Errorf(errCABundleKeyFmt, ref.Key, "Secret", ref.Namespace, ref.Name)
+		}
+		return data, nil
+	case spec.CABundleConfigMapRef != nil:
+		ref := spec.CABundleConfigMapRef
+		cm := &corev1.ConfigMap{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, cm); err != nil {
+			return nil, errors.Wrap(err, errGetCABundleConfigMap)
+		}
+		data, ok := cm.Data[ref.Key]
+		if !ok {
+			return nil, errors.Errorf(errCABundleKeyFmt, ref.Key, "ConfigMap", ref.Namespace, ref.Name)
+		}
+		return []byte(data), nil
+	default:
+		return []byte(spec.CABundle), nil
+	}
+}
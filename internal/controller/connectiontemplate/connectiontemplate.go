@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package connectiontemplate renders user supplied Go templates into the
+// connection details of managed resources.
+package connectiontemplate
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"sort"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+)
+
+const (
+	errParse   = "cannot parse connection template %q"
+	errExecute = "cannot render connection template %q"
+)
+
+// Data is what connection templates are rendered against.
+type Data struct {
+	AccessKey   string
+	SecretKey   string
+	UserID      string
+	GroupID     string
+	CanonicalID string
+	// Endpoint is the Cloudian Admin API endpoint of the ProviderConfig, or
+	// the first of its endpoints.
+	Endpoint string
+	// S3Endpoint and Region are the S3 service of the ProviderConfig.
+	S3Endpoint string
+	Region     string
+}
+
+var funcs = template.FuncMap{
+	// json encodes a value as JSON, e.g. to quote strings in a JSON document.
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// Render renders each template with data, and returns the results keyed like
+// the templates. Referencing unknown fields is an error.
+func Render(templates map[string]string, data Data) (managed.ConnectionDetails, error) {
+	keys := make([]string, 0, len(templates))
+	for key := range templates {
+		keys = append(keys, key)
+	}
+	// Render in a stable order, so that errors are reported consistently.
+	sort.Strings(keys)
+
+	details := make(managed.ConnectionDetails, len(templates))
+	for _, key := range keys {
+		tmpl, err := template.New(key).Funcs(funcs).Option("missingkey=error").Parse(templates[key])
+		if err != nil {
+			return nil, errors.Wrapf(err, errParse, key)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, errors.Wrapf(err, errExecute, key)
+		}
+		details[key] = buf.Bytes()
+	}
+	return details, nil
+}
+
+// References reports whether any of the templates may reference a field of
+// Data, so that data that is expensive to get can be left out otherwise.
+// Templates that use the data as a whole, or cannot be parsed, are assumed to
+// reference it.
+func References(templates map[string]string, field string) bool {
+	for key, text := range templates {
+		tmpl, err := template.New(key).Funcs(funcs).Parse(text)
+		if err != nil {
+			return true
+		}
+		for _, t := range tmpl.Templates() {
+			if t.Tree != nil && references(t.Tree.Root, field) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func references(node parse.Node, field string) bool {
+	switch n := node.(type) {
+	case *parse.DotNode:
+		return true
+	case *parse.FieldNode:
+		return slices.Contains(n.Ident, field)
+	case *parse.VariableNode:
+		return slices.Contains(n.Ident, field) || len(n.Ident) == 1 && n.Ident[0] == "$"
+	case *parse.ChainNode:
+		return slices.Contains(n.Field, field) || references(n.Node, field)
+	case *parse.ListNode:
+		return n != nil && slices.ContainsFunc(n.Nodes, func(c parse.Node) bool { return references(c, field) })
+	case *parse.ActionNode:
+		return references(n.Pipe, field)
+	case *parse.PipeNode:
+		return n != nil && slices.ContainsFunc(n.Cmds, func(c *parse.CommandNode) bool { return references(c, field) })
+	case *parse.CommandNode:
+		return slices.ContainsFunc(n.Args, func(c parse.Node) bool { return references(c, field) })
+	case *parse.IfNode:
+		return references(&n.BranchNode, field)
+	case *parse.RangeNode:
+		return references(&n.BranchNode, field)
+	case *parse.WithNode:
+		return references(&n.BranchNode, field)
+	case *parse.BranchNode:
+		return references(n.Pipe, field) || references(n.List, field) || references(n.ElseList, field)
+	case *parse.TemplateNode:
+		return references(n.Pipe, field)
+	}
+	return false
+}
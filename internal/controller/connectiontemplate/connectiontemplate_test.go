@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connectiontemplate
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+)
+
+func TestRender(t *testing.T) {
+	data := Data{
+		AccessKey:  "AKID",
+		SecretKey:  "se\"cret",
+		UserID:     "bar",
+		GroupID:    "foo",
+		S3Endpoint: "https://s3.example.com",
+	}
+
+	type want struct {
+		details managed.ConnectionDetails
+		err     bool
+	}
+
+	cases := map[string]struct {
+		reason    string
+		templates map[string]string
+		want      want
+	}{
+		"Properties": {
+			reason: "Fields should be rendered into the template.",
+			templates: map[string]string{
+				"application.properties": "s3.endpoint={{ .S3Endpoint }}\ns3.access-key={{ .AccessKey }}\n",
+			},
+			want: want{details: managed.ConnectionDetails{
+				"application.properties": []byte("s3.endpoint=https://s3.example.com\ns3.access-key=AKID\n"),
+			}},
+		},
+		"JSON": {
+			reason: "The json function should quote values.",
+			templates: map[string]string{
+				"config.json": `{"user": {{ json .UserID }}, "secret": {{ json .SecretKey }}}`,
+			},
+			want: want{details: managed.ConnectionDetails{
+				"config.json": []byte(`{"user": "bar", "secret": "se\"cret"}`),
+			}},
+		},
+		"ParseError": {
+			reason:    "Malformed templates should return an error.",
+			templates: map[string]string{"broken": "{{ .AccessKey "},
+			want:      want{err: true},
+		},
+		"UnknownField": {
+			reason:    "Referencing unknown fields should return an error.",
+			templates: map[string]string{"unknown": "{{ .Password }}"},
+			want:      want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Render(tc.templates, data)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\nRender(...): want error %t, got %v\n", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.details, got); diff != "" {
+				t.Errorf("\n%s\nRender(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReferences(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		templates map[string]string
+		want      bool
+	}{
+		"NotReferenced": {
+			reason:    "Templates using other fields should not reference the field.",
+			templates: map[string]string{"a": "{{ .AccessKey }}", "b": `{{ if .Region }}{{ json .Region }}{{ end }}`},
+		},
+		"Field": {
+			reason:    "A template using the field should reference it.",
+			templates: map[string]string{"a": "{{ .AccessKey }}", "b": "id={{ .CanonicalID }}"},
+			want:      true,
+		},
+		"Nested": {
+			reason:    "A template using the field in a pipeline of a branch should reference it.",
+			templates: map[string]string{"a": `{{ range $i := .UserID }}{{ with $.CanonicalID | printf "%s" }}{{ end }}{{ end }}`},
+			want:      true,
+		},
+		"Dot": {
+			reason:    "A template using the data as a whole may reference any field.",
+			templates: map[string]string{"a": "{{ json . }}"},
+			want:      true,
+		},
+		"Invalid": {
+			reason:    "A template that cannot be parsed should be assumed to reference the field.",
+			templates: map[string]string{"a": "{{ .AccessKey"},
+			want:      true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := References(tc.templates, "CanonicalID"); got != tc.want {
+				t.Errorf("\n%s\nReferences(...): want %t, got %t\n", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
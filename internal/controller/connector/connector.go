@@ -0,0 +1,370 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package connector builds Cloudian clients from the ProviderConfig of a
+// managed resource, and shares them between all controllers.
+package connector
+
+import (
+	"context"
+	"crypto/tls"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/controller/config"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+const (
+	errNoPCRef      = "managed resource has no ProviderConfig reference"
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetPC        = "cannot get ProviderConfig"
+	errGetCreds     = "cannot get credentials"
+	errGetTLS       = "cannot get TLS configuration"
+	errGetVersion   = "cannot get version of ProviderConfig references"
+	errNewClient    = "cannot create new Service"
+
+	errUnauthorized = "Cloudian API rejected the credentials of ProviderConfig %q"
+	errForbidden    = "Cloudian API user of ProviderConfig %q is not allowed to perform the operation"
+	errRetryable    = "Cloudian API is temporarily unavailable"
+)
+
+// clients is shared by all controllers, so that managed resources using the
+// same ProviderConfig also use the same client.
+var clients = &clientCache{entries: map[string]clientCacheEntry{}}
+
+// A NewExternalFn produces the ExternalClient of a controller from a Cloudian
+// client and the ProviderConfig it was built from.
+type NewExternalFn func(svc *cloudian.Client, pc *apisv1alpha1.ProviderConfig) managed.ExternalClient
+
+// A NewClientFn builds a Cloudian client from a ProviderConfig and its
+// resolved credentials and TLS configurations of the Cloudian API and S3.
+type NewClientFn func(pc *apisv1alpha1.ProviderConfig, authHeader string, tlsConfig, s3TLSConfig *tls.Config) (*cloudian.Client, error)
+
+// A Connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type Connector struct {
+	kube        client.Client
+	usage       resource.Tracker
+	clients     *clientCache
+	failures    *failures
+	newClient   NewClientFn
+	newExternal NewExternalFn
+}
+
+// New returns a Connector that produces ExternalClients by calling
+// newExternal with a Cloudian client for the managed resource's ProviderConfig.
+func New(kube client.Client, newExternal NewExternalFn) *Connector {
+	return &Connector{
+		kube:        kube,
+		usage:       resource.NewProviderConfigUsageTracker(kube, &apisv1alpha1.ProviderConfigUsage{}),
+		clients:     clients,
+		failures:    &failures{reasons: map[types.NamespacedName]xpv1.ConditionReason{}},
+		newClient:   newClient,
+		newExternal: newExternal,
+	}
+}
+
+// Defaults of an unset ClientConfig.
+const (
+	defaultTimeout           = 30 * time.Second
+	defaultRetries           = 3
+	defaultRetryWait         = 500 * time.Millisecond
+	defaultRetryMaxWait      = 10 * time.Second
+	defaultFailureThreshold  = 5
+	defaultOpenDuration      = 30 * time.Second
+	defaultRequestsPerSecond = 10
+	defaultBurst             = 20
+)
+
+func newClient(pc *apisv1alpha1.ProviderConfig, authHeader string, tlsConfig, s3TLSConfig *tls.Config) (*cloudian.Client, error) {
+	opts := append([]func(*cloudian.Client){cloudian.WithTLSConfig(tlsConfig), cloudian.WithS3TLSConfig(s3TLSConfig)}, clientOptions(pc.Spec.Client)...)
+	opts = append(opts, cloudian.WithEndpoints(pc.Spec.GetEndpoints(), cloudian.EndpointPolicy(pc.Spec.EndpointPolicy)))
+	return cloudian.NewClient("", authHeader, opts...), nil
+}
+
+// clientOptions returns the timeout, retry, circuit breaker and rate limit
+// options of a ClientConfig, with defaults for unset fields.
+func clientOptions(cfg *apisv1alpha1.ClientConfig) []func(*cloudian.Client) {
+	if cfg == nil {
+		cfg = &apisv1alpha1.ClientConfig{}
+	}
+	cb := cfg.CircuitBreaker
+	if cb == nil {
+		cb = &apisv1alpha1.CircuitBreakerConfig{}
+	}
+	rl := cfg.RateLimit
+	if rl == nil {
+		rl = &apisv1alpha1.RateLimitConfig{}
+	}
+	duration := func(d *metav1.Duration, def time.Duration) time.Duration {
+		if d == nil {
+			return def
+		}
+		return d.Duration
+	}
+
+	opts := []func(*cloudian.Client){
+		cloudian.WithTimeout(duration(cfg.Timeout, defaultTimeout)),
+		cloudian.WithRetries(int(ptr.Deref(cfg.Retries, defaultRetries)),
+			duration(cfg.RetryWait, defaultRetryWait),
+			duration(cfg.RetryMaxWait, defaultRetryMaxWait)),
+	}
+	if threshold := ptr.Deref(cb.FailureThreshold, defaultFailureThreshold); threshold > 0 {
+		opts = append(opts, cloudian.WithCircuitBreaker(int(threshold), duration(cb.OpenDuration, defaultOpenDuration)))
+	}
+	if rps := requestsPerSecond(rl); rps > 0 {
+		opts = append(opts, cloudian.WithRateLimit(rps, int(ptr.Deref(rl.Burst, defaultBurst))))
+	}
+	return opts
+}
+
+// requestsPerSecond returns the possibly fractional request rate of a
+// RateLimitConfig, or the default rate if unset.
+func requestsPerSecond(rl *apisv1alpha1.RateLimitConfig) float64 {
+	if rl.RequestsPerSecond == nil {
+		return defaultRequestsPerSecond
+	}
+	return rl.RequestsPerSecond.AsApproximateFloat64()
+}
+
+// Connect produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting a Cloudian client for the ProviderConfig.
+// 4. Passing the client to the controller's NewExternalFn.
+//
+// Errors of the Cloudian API returned by the ExternalClient are described
+// alike for all controllers.
+func (c *Connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ref := mg.GetProviderConfigReference()
+	if ref == nil {
+		return nil, errors.New(errNoPCRef)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	svc, err := c.Client(ctx, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{ExternalClient: c.newExternal(svc, pc), providerConfig: pc.Name, clients: c.clients, failures: c.failures}, nil
+}
+
+// external decorates the ExternalClient of a controller to describe errors of
+// the Cloudian API, which the managed reconciler reports in the Synced
+// condition of the managed resource. The class of an error is reported in the
+// CloudianAPI condition, and recorded in failures to requeue the managed
+// resource accordingly.
+type external struct {
+	managed.ExternalClient
+	providerConfig string
+	clients        *clientCache
+	failures       *failures
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	return o, e.describe(mg, err)
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	c, err := e.ExternalClient.Create(ctx, mg)
+	return c, e.describe(mg, err)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	u, err := e.ExternalClient.Update(ctx, mg)
+	return u, e.describe(mg, err)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	d, err := e.ExternalClient.Delete(ctx, mg)
+	return d, e.describe(mg, err)
+}
+
+func (e *external) describe(mg resource.Managed, err error) error {
+	var reason xpv1.ConditionReason
+	switch {
+	case err == nil:
+		mg.SetConditions(apisv1alpha1.APIAvailable())
+		return nil
+	case cloudian.IsUnauthorized(err):
+		// Credentials from the environment or filesystem are only read when
+		// a client is built, so build a new one in case they were rotated.
+		e.clients.delete(e.providerConfig)
+		reason, err = apisv1alpha1.ReasonUnauthorized, errors.Wrapf(err, errUnauthorized, e.providerConfig)
+	case cloudian.IsForbidden(err):
+		// Unlike rejected credentials, a forbidden operation does not evict the
+		// client, whose rate limiter, circuit breaker and endpoint health are
+		// shared by all managed resources of the ProviderConfig.
+		reason, err = apisv1alpha1.ReasonForbidden, errors.Wrapf(err, errForbidden, e.providerConfig)
+	case cloudian.IsConflict(err):
+		reason = apisv1alpha1.ReasonConflict
+	case cloudian.IsRetryable(err):
+		reason, err = apisv1alpha1.ReasonAPIUnavailable, errors.Wrap(err, errRetryable)
+	default:
+		return err
+	}
+	mg.SetConditions(apisv1alpha1.APIError(reason, err))
+	e.failures.record(mg, reason)
+	return err
+}
+
+// Client returns a Cloudian client for a ProviderConfig. Clients are cached
+// until the generation of the ProviderConfig, or the resource version of a
+// Secret or ConfigMap it references, changes. Credentials from the environment
+// or filesystem are read when a client is built.
+func (c *Connector) Client(ctx context.Context, pc *apisv1alpha1.ProviderConfig) (*cloudian.Client, error) {
+	version, err := c.version(ctx, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetVersion)
+	}
+	if svc, ok := c.clients.get(pc.Name, version); ok {
+		return svc, nil
+	}
+
+	authHeader, err := config.AuthHeader(ctx, c.kube, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	tlsConfig, err := config.TLSConfig(ctx, c.kube, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetTLS)
+	}
+
+	s3TLSConfig, err := config.S3TLSConfig(ctx, c.kube, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetTLS)
+	}
+
+	svc, err := c.newClient(pc, authHeader, tlsConfig, s3TLSConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	c.clients.set(pc.Name, version, svc)
+	return svc, nil
+}
+
+// version identifies the state of a ProviderConfig and the objects it
+// references, by its UID and generation and their resource versions.
+func (c *Connector) version(ctx context.Context, pc *apisv1alpha1.ProviderConfig) (string, error) {
+	parts := []string{string(pc.UID), strconv.FormatInt(pc.Generation, 10)}
+	for _, obj := range references(pc) {
+		if err := c.kube.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			return "", err
+		}
+		parts = append(parts, obj.GetResourceVersion())
+	}
+	return strings.Join(parts, "/"), nil
+}
+
+// references returns the Secrets and ConfigMaps read to build a client for
+// the ProviderConfig.
+func references(pc *apisv1alpha1.ProviderConfig) []client.Object {
+	var refs []client.Object
+	secret := func(ref xpv1.SecretReference) {
+		refs = append(refs, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: ref.Namespace, Name: ref.Name}})
+	}
+
+	if ah := pc.Spec.AuthHeader; ah != nil && ah.Source == xpv1.CredentialsSourceSecret && ah.SecretRef != nil {
+		secret(ah.SecretRef.SecretReference)
+	}
+	if creds := pc.Spec.Credentials; creds != nil && creds.Source == xpv1.CredentialsSourceSecret {
+		switch {
+		case creds.UsernamePasswordSecretRef != nil:
+			secret(creds.UsernamePasswordSecretRef.SecretReference)
+		case creds.SecretRef != nil:
+			secret(creds.SecretRef.SecretReference)
+		}
+	}
+	tlsConfigs := []*apisv1alpha1.TLSConfig{pc.Spec.TLS}
+	if pc.Spec.S3 != nil {
+		tlsConfigs = append(tlsConfigs, pc.Spec.S3.TLS)
+	}
+	for _, t := range tlsConfigs {
+		switch {
+		case t == nil:
+		case t.CABundleSecretRef != nil:
+			secret(t.CABundleSecretRef.SecretReference)
+		case t.CABundleConfigMapRef != nil:
+			ref := t.CABundleConfigMapRef
+			refs = append(refs, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: ref.Namespace, Name: ref.Name}})
+		}
+	}
+
+	return refs
+}
+
+type clientCacheEntry struct {
+	version string
+	client  *cloudian.Client
+}
+
+// A clientCache holds the most recent client of each ProviderConfig.
+type clientCache struct {
+	mu      sync.Mutex
+	entries map[string]clientCacheEntry
+}
+
+func (c *clientCache) get(name, version string) (*cloudian.Client, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[name]
+	if !ok || e.version != version {
+		return nil, false
+	}
+	return e.client, true
+}
+
+func (c *clientCache) set(name, version string, svc *cloudian.Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[name] = clientCacheEntry{version: version, client: svc}
+}
+
+func (c *clientCache) delete(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, name)
+}
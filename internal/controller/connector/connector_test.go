@@ -0,0 +1,279 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connector
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+func TestClientCache(t *testing.T) {
+	secretVersion := "1"
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			s := obj.(*corev1.Secret)
+			s.ResourceVersion = secretVersion
+			s.Data = map[string][]byte{"username": []byte("sysadmin"), "password": []byte("password")}
+			return nil
+		},
+	}
+	c := &Connector{
+		kube:      kube,
+		clients:   &clientCache{entries: map[string]clientCacheEntry{}},
+		newClient: newClient,
+	}
+	pc := &apisv1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", UID: "uid", Generation: 1},
+		Spec: apisv1alpha1.ProviderConfigSpec{
+			Endpoint: "https://cloudian.invalid:19443",
+			Credentials: &apisv1alpha1.UserCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				UsernamePasswordSecretRef: &apisv1alpha1.UsernamePasswordSecretReference{
+					SecretReference: xpv1.SecretReference{Name: "cloudian", Namespace: "crossplane-system"},
+					UsernameKey:     "username",
+					PasswordKey:     "password",
+				},
+			},
+		},
+	}
+
+	first, err := c.Client(context.Background(), pc)
+	if err != nil {
+		t.Fatalf("Client(...): %v", err)
+	}
+
+	if got, _ := c.Client(context.Background(), pc); got != first {
+		t.Error("Client(...): expected cached client for unchanged ProviderConfig")
+	}
+
+	secretVersion = "2"
+	second, _ := c.Client(context.Background(), pc)
+	if second == first {
+		t.Error("Client(...): expected new client after credentials Secret changed")
+	}
+
+	pc.Generation = 2
+	if got, _ := c.Client(context.Background(), pc); got == second {
+		t.Error("Client(...): expected new client after ProviderConfig changed")
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	cases := map[string]struct {
+		err     error
+		prefix  string
+		reason  xpv1.ConditionReason
+		evicted bool
+	}{
+		"Unauthorized": {
+			err:     &cloudian.APIError{StatusCode: 401},
+			prefix:  `Cloudian API rejected the credentials of ProviderConfig "default": `,
+			reason:  apisv1alpha1.ReasonUnauthorized,
+			evicted: true,
+		},
+		"Forbidden": {
+			err:    &cloudian.APIError{StatusCode: 403},
+			prefix: `Cloudian API user of ProviderConfig "default" is not allowed to perform the operation: `,
+			reason: apisv1alpha1.ReasonForbidden,
+		},
+		"Conflict": {
+			err:    &cloudian.APIError{StatusCode: 409},
+			reason: apisv1alpha1.ReasonConflict,
+		},
+		"Retryable": {
+			err:    &cloudian.APIError{StatusCode: 503},
+			prefix: "Cloudian API is temporarily unavailable: ",
+			reason: apisv1alpha1.ReasonAPIUnavailable,
+		},
+		"Other": {
+			err: &cloudian.APIError{StatusCode: 400},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			clients := &clientCache{entries: map[string]clientCacheEntry{}}
+			clients.set("default", "1", &cloudian.Client{})
+			e := &external{providerConfig: "default", clients: clients}
+			mg := &fake.Managed{}
+
+			err := e.describe(mg, tc.err)
+			if !errors.Is(err, tc.err) {
+				t.Errorf("describe(...): expected %v to wrap %v", err, tc.err)
+			}
+			if !strings.HasPrefix(err.Error(), tc.prefix) {
+				t.Errorf("describe(...) = %q, want prefix %q", err.Error(), tc.prefix)
+			}
+			if got := mg.GetCondition(apisv1alpha1.TypeCloudianAPI).Reason; got != tc.reason {
+				t.Errorf("describe(...): condition reason = %q, want %q", got, tc.reason)
+			}
+			if _, ok := clients.get("default", "1"); ok == tc.evicted {
+				t.Errorf("describe(...): client cached = %v, want %v", ok, !tc.evicted)
+			}
+		})
+	}
+}
+
+func TestReconciler(t *testing.T) {
+	backoff := reconcile.Result{Requeue: true}
+	cases := map[string]struct {
+		err  error
+		want reconcile.Result
+	}{
+		"Success": {
+			want: reconcile.Result{RequeueAfter: time.Minute},
+		},
+		"Unauthorized": {
+			err:  &cloudian.APIError{StatusCode: 401},
+			want: reconcile.Result{RequeueAfter: time.Minute},
+		},
+		"Forbidden": {
+			err:  &cloudian.APIError{StatusCode: 403},
+			want: reconcile.Result{RequeueAfter: time.Minute},
+		},
+		"Conflict": {
+			err:  &cloudian.APIError{StatusCode: 409},
+			want: backoff,
+		},
+		"Retryable": {
+			err:  &cloudian.APIError{StatusCode: 503},
+			want: reconcile.Result{RequeueAfter: requeueUnavailable},
+		},
+		"Other": {
+			err:  &cloudian.APIError{StatusCode: 400},
+			want: backoff,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &Connector{failures: &failures{reasons: map[types.NamespacedName]xpv1.ConditionReason{}}}
+			e := &external{clients: &clientCache{entries: map[string]clientCacheEntry{}}, failures: c.failures}
+			// Like the managed reconciler, requeue errors with backoff and
+			// successes after the poll interval.
+			r := c.Reconciler(reconcile.Func(func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+				mg := &fake.Managed{ObjectMeta: metav1.ObjectMeta{Name: req.Name}}
+				if err := e.describe(mg, tc.err); err != nil {
+					return backoff, nil
+				}
+				return reconcile.Result{RequeueAfter: time.Minute}, nil
+			}), time.Minute)
+
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "foo"}})
+			if err != nil {
+				t.Fatalf("r.Reconcile(...): %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("r.Reconcile(...) = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	cases := map[string]struct {
+		cfg  *apisv1alpha1.ClientConfig
+		want time.Duration
+	}{
+		"Default": {
+			want: 30 * time.Second,
+		},
+		"Configured": {
+			cfg:  &apisv1alpha1.ClientConfig{Timeout: &metav1.Duration{Duration: 5 * time.Second}},
+			want: 5 * time.Second,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{Client: tc.cfg}}
+			svc, err := newClient(pc, "", nil, nil)
+			if err != nil {
+				t.Fatalf("newClient(...): %v", err)
+			}
+			if got := svc.HTTPClient().Timeout; got != tc.want {
+				t.Errorf("newClient(...): timeout = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRequestsPerSecond(t *testing.T) {
+	cases := map[string]struct {
+		rl   *apisv1alpha1.RateLimitConfig
+		want float64
+	}{
+		"Default": {
+			rl:   &apisv1alpha1.RateLimitConfig{},
+			want: 10,
+		},
+		"Integer": {
+			rl:   &apisv1alpha1.RateLimitConfig{RequestsPerSecond: ptr.To(resource.MustParse("25"))},
+			want: 25,
+		},
+		"BelowOne": {
+			rl:   &apisv1alpha1.RateLimitConfig{RequestsPerSecond: ptr.To(resource.MustParse("500m"))},
+			want: 0.5,
+		},
+		"Disabled": {
+			rl:   &apisv1alpha1.RateLimitConfig{RequestsPerSecond: ptr.To(resource.MustParse("0"))},
+			want: 0,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := requestsPerSecond(tc.rl); got != tc.want {
+				t.Errorf("requestsPerSecond(...) = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClientS3TLS(t *testing.T) {
+	pc := &apisv1alpha1.ProviderConfig{}
+	svc, err := newClient(pc, "", &tls.Config{ServerName: "admin.example.com"}, &tls.Config{ServerName: "s3.example.com"})
+	if err != nil {
+		t.Fatalf("newClient(...): %v", err)
+	}
+	if got := svc.HTTPClient().Transport.(*http.Transport).TLSClientConfig.ServerName; got != "admin.example.com" {
+		t.Errorf("newClient(...): Cloudian API server name = %q, want admin.example.com", got)
+	}
+	if got := svc.S3HTTPClient().Transport.(*http.Transport).TLSClientConfig.ServerName; got != "s3.example.com" {
+		t.Errorf("newClient(...): S3 server name = %q, want s3.example.com", got)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connector
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+)
+
+// requeueUnavailable is how long a managed resource waits to be reconciled
+// again after the Cloudian API was unavailable.
+const requeueUnavailable = 15 * time.Second
+
+// failures records the class of the Cloudian API error a managed resource
+// failed with during its current reconcile.
+type failures struct {
+	mu      sync.Mutex
+	reasons map[types.NamespacedName]xpv1.ConditionReason
+}
+
+func (f *failures) record(mg resource.Managed, reason xpv1.ConditionReason) {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reasons[types.NamespacedName{Namespace: mg.GetNamespace(), Name: mg.GetName()}] = reason
+}
+
+func (f *failures) take(name types.NamespacedName) (xpv1.ConditionReason, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	reason, ok := f.reasons[name]
+	delete(f.reasons, name)
+	return reason, ok
+}
+
+// Reconciler wraps the managed reconciler of a controller, which requeues
+// every error with exponential backoff, to requeue managed resources by the
+// class of the Cloudian API error they failed with. While the Cloudian API is
+// unavailable they are retried at a fixed interval, without backing off
+// further. Rejected credentials and forbidden operations are not retried
+// before the next poll, as they need to be fixed first. Other errors are left
+// to the managed reconciler.
+func (c *Connector) Reconciler(r reconcile.Reconciler, pollInterval time.Duration) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		result, err := r.Reconcile(ctx, req)
+		reason, failed := c.failures.take(req.NamespacedName)
+		if err != nil || !failed {
+			return result, err
+		}
+		switch reason {
+		case apisv1alpha1.ReasonAPIUnavailable:
+			return reconcile.Result{RequeueAfter: requeueUnavailable}, nil
+		case apisv1alpha1.ReasonUnauthorized, apisv1alpha1.ReasonForbidden:
+			return reconcile.Result{RequeueAfter: pollInterval}, nil
+		default:
+			return result, nil
+		}
+	})
+}
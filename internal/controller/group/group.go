@@ -20,10 +20,8 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/connection"
@@ -36,33 +34,29 @@ import (
 
 	"github.com/statnett/provider-cloudian/apis/user/v1alpha1"
 	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/controller/connector"
+	"github.com/statnett/provider-cloudian/internal/controller/lateinit"
+	"github.com/statnett/provider-cloudian/internal/controller/usage"
 	"github.com/statnett/provider-cloudian/internal/features"
+	"github.com/statnett/provider-cloudian/internal/metrics"
 	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
 )
 
 const (
-	errNotGroup     = "managed resource is not a Group custom resource"
-	errTrackPCUsage = "cannot track ProviderConfig usage"
-	errGetPC        = "cannot get ProviderConfig"
-	errGetCreds     = "cannot get credentials"
-
-	errNewClient   = "cannot create new Service"
-	errCreateGroup = "cannot create Group"
-	errDeleteGroup = "cannot delete Group"
-	errGetGroup    = "cannot get Group"
-	errUpdateGroup = "cannot update Group"
+	errNotGroup = "managed resource is not a Group custom resource"
+
+	errCreateGroup   = "cannot create Group"
+	errDeleteGroup   = "cannot delete Group"
+	errGetGroup      = "cannot get Group"
+	errUpdateGroup   = "cannot update Group"
+	errGroupExists   = "Group already exists in Cloudian, and is observed on the next reconcile"
+	errListUsers     = "cannot list users of Group"
+	errGroupHasUsers = "cannot delete Group while it has users, unless memberDeletionPolicy is Cascade or Orphan"
 )
 
-var (
-	newCloudianService = func(providerConfig *apisv1alpha1.ProviderConfig, authHeader string) (*cloudian.Client, error) {
-		// FIXME: Don't require InsecureSkipVerify
-		return cloudian.NewClient(
-			providerConfig.Spec.Endpoint,
-			authHeader,
-			cloudian.WithInsecureTLSVerify(true),
-		), nil
-	}
-)
+// maxBlockingUsers is how many users of a group that cannot be deleted are
+// listed in its DeletionBlocked condition.
+const maxBlockingUsers = 10
 
 // Setup adds a controller that reconciles Group managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
@@ -73,65 +67,39 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
-	r := managed.NewReconciler(mgr,
-		resource.ManagedKind(v1alpha1.GroupGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
-			kube:         mgr.GetClient(),
-			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newServiceFn: newCloudianService}),
+	var tenants *metrics.Tenants
+	if o.Features.Enabled(features.EnableAlphaTenantMetrics) {
+		tenants = metrics.DefaultTenants
+	}
+
+	conn := connector.New(mgr.GetClient(), newExternalFn(tenants))
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(conn),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		managed.WithConnectionPublishers(cps...))
+		managed.WithConnectionPublishers(cps...),
+	}
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.GroupGroupVersionKind), opts...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1alpha1.Group{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
-}
-
-// A connector is expected to produce an ExternalClient when its Connect method
-// is called.
-type connector struct {
-	kube         client.Client
-	usage        resource.Tracker
-	newServiceFn func(providerConfig *apisv1alpha1.ProviderConfig, authHeader string) (*cloudian.Client, error)
+		Complete(ratelimiter.NewReconciler(name, conn.Reconciler(r, o.PollInterval), o.GlobalRateLimiter))
 }
 
-// Connect typically produces an ExternalClient by:
-// 1. Tracking that the managed resource is using a ProviderConfig.
-// 2. Getting the managed resource's ProviderConfig.
-// 3. Getting the credentials specified by the ProviderConfig.
-// 4. Using the credentials to form a client.
-func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mg.(*v1alpha1.Group)
-	if !ok {
-		return nil, errors.New(errNotGroup)
-	}
-
-	if err := c.usage.Track(ctx, mg); err != nil {
-		return nil, errors.Wrap(err, errTrackPCUsage)
-	}
-
-	pc := &apisv1alpha1.ProviderConfig{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
-		return nil, errors.Wrap(err, errGetPC)
-	}
-
-	cd := pc.Spec.AuthHeader
-	authHeader, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
-	if err != nil {
-		return nil, errors.Wrap(err, errGetCreds)
-	}
-
-	svc, err := c.newServiceFn(pc, string(authHeader))
-	if err != nil {
-		return nil, errors.Wrap(err, errNewClient)
+// newExternalFn returns a function that produces an ExternalClient from a
+// Cloudian client, which records observed usage in tenants.
+func newExternalFn(tenants *metrics.Tenants) connector.NewExternalFn {
+	return func(svc *cloudian.Client, pc *apisv1alpha1.ProviderConfig) managed.ExternalClient {
+		return &external{cloudianService: svc, usage: pc.Spec.Usage, tenants: tenants}
 	}
-
-	return &external{cloudianService: svc}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
@@ -140,6 +108,8 @@ type external struct {
 	// A 'client' used to connect to the external resource API. In practice this
 	// would be something like an AWS SDK client.
 	cloudianService *cloudian.Client
+	usage           *apisv1alpha1.UsageConfig
+	tenants         *metrics.Tenants
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -160,7 +130,24 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errGetGroup)
 	}
+	if meta.WasDeleted(cr) && cr.Spec.ForProvider.MemberDeletionPolicy == v1alpha1.MemberDeletionOrphan {
+		// A group with users is left in Cloudian, so report it as gone to
+		// let the managed resource be deleted. An empty group is deleted.
+		users, _, err := c.users(ctx, externalName)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errListUsers)
+		}
+		if len(users) > 0 {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+	}
 
+	lastUsage := cr.Status.AtProvider.Usage
+	cr.Status.AtProvider = newObservation(*observedGroup)
+	guid := cloudian.GroupUserID{GroupID: externalName, UserID: "*"}
+	cr.Status.AtProvider.Usage = usage.Update(ctx, c.cloudianService, c.usage, cr, guid, lastUsage)
+	usage.Record(c.tenants, guid, cr.Status.AtProvider.Usage)
+	lateInitialized := lateInitialize(&cr.Spec.ForProvider, *observedGroup)
 	cr.SetConditions(xpv1.Available())
 
 	return managed.ExternalObservation{
@@ -169,6 +156,10 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		// (re)create the resource, or that it has successfully been deleted.
 		ResourceExists: true,
 
+		// Return true when unset spec fields were filled from the external
+		// resource, so that the managed resource reconciler persists them.
+		ResourceLateInitialized: lateInitialized,
+
 		// Return false when the external resource exists, but it not up to date
 		// with the desired managed resource state. This lets the managed
 		// resource reconciler know that it needs to call Update.
@@ -188,7 +179,11 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Creating())
 
-	if err := c.cloudianService.CreateGroup(ctx, newCloudianGroup(meta.GetExternalName(mg), cr.Spec.ForProvider)); err != nil {
+	err := c.cloudianService.CreateGroup(ctx, newCloudianGroup(meta.GetExternalName(mg), cr.Spec.ForProvider))
+	if cloudian.IsConflict(err) {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGroupExists)
+	}
+	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreateGroup)
 	}
 
@@ -224,13 +219,62 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Deleting())
 
-	if err := c.cloudianService.DeleteGroup(ctx, meta.GetExternalName(mg)); err != nil {
-		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteGroup)
+	groupID := meta.GetExternalName(mg)
+	if err := c.deleteGroup(ctx, cr, groupID); err != nil {
+		return managed.ExternalDelete{}, err
 	}
+	c.tenants.DeleteUsage(cloudian.GroupUserID{GroupID: groupID, UserID: "*"})
 
 	return managed.ExternalDelete{}, nil
 }
 
+// deleteGroup deletes a group according to its member deletion policy.
+func (c *external) deleteGroup(ctx context.Context, cr *v1alpha1.Group, groupID string) error {
+	policy := cr.Spec.ForProvider.MemberDeletionPolicy
+	if policy == v1alpha1.MemberDeletionCascade {
+		err := c.cloudianService.DeleteGroupRecursive(ctx, groupID)
+		if err != nil && !errors.Is(err, cloudian.ErrNotFound) {
+			return errors.Wrap(err, errDeleteGroup)
+		}
+		return nil
+	}
+
+	users, more, err := c.users(ctx, groupID)
+	if err != nil {
+		return errors.Wrap(err, errListUsers)
+	}
+	switch {
+	case len(users) == 0:
+		err := c.cloudianService.DeleteGroup(ctx, groupID)
+		if err != nil && !errors.Is(err, cloudian.ErrNotFound) {
+			return errors.Wrap(err, errDeleteGroup)
+		}
+		return nil
+	case policy == v1alpha1.MemberDeletionOrphan:
+		// Leave the group in Cloudian with its users.
+		return nil
+	default:
+		cr.SetConditions(v1alpha1.DeletionBlocked(users, more))
+		return errors.New(errGroupHasUsers)
+	}
+}
+
+// users returns the IDs of up to maxBlockingUsers users of a group, and
+// whether it has more.
+func (c *external) users(ctx context.Context, groupID string) ([]string, bool, error) {
+	var users []string
+	for user, err := range c.cloudianService.ListUsers(ctx, groupID, cloudian.ListUsersOptions{PageSize: maxBlockingUsers + 1}) {
+		if err != nil {
+			return nil, false, err
+		}
+		if len(users) == maxBlockingUsers {
+			return users, true, nil
+		}
+		users = append(users, user.UserID)
+	}
+	return users, false, nil
+}
+
 func (c *external) Disconnect(ctx context.Context) error {
 	return nil
 }
@@ -241,7 +285,7 @@ func isUpToDate(name string, desired v1alpha1.GroupParameters, observed cloudian
 
 func newCloudianGroup(name string, gp v1alpha1.GroupParameters) cloudian.Group {
 	return cloudian.Group{
-		Active:             gp.Active,
+		Active:             ptr.Deref(gp.Active, true),
 		GroupID:            name,
 		GroupName:          gp.GroupName,
 		LDAPEnabled:        ptr.Deref(gp.LDAPEnabled, false),
@@ -251,5 +295,42 @@ func newCloudianGroup(name string, gp v1alpha1.GroupParameters) cloudian.Group {
 		LDAPSearchUserBase: ptr.Deref(gp.LDAPSearchUserBase, ""),
 		LDAPServerURL:      ptr.Deref(gp.LDAPServerURL, ""),
 		LDAPUserDNTemplate: ptr.Deref(gp.LDAPUserDNTemplate, ""),
+		RatingPlanID:       ptr.Deref(gp.RatingPlanID, ""),
+	}
+}
+
+func newObservation(g cloudian.Group) v1alpha1.GroupObservation {
+	return v1alpha1.GroupObservation{
+		Active:             g.Active,
+		GroupName:          g.GroupName,
+		LDAPEnabled:        g.LDAPEnabled,
+		LDAPGroup:          g.LDAPGroup,
+		LDAPMatchAttribute: g.LDAPMatchAttribute,
+		LDAPSearch:         g.LDAPSearch,
+		LDAPSearchUserBase: g.LDAPSearchUserBase,
+		LDAPServerURL:      g.LDAPServerURL,
+		LDAPUserDNTemplate: g.LDAPUserDNTemplate,
+		RatingPlanID:       g.RatingPlanID,
+	}
+}
+
+// lateInitialize fills unset parameters from the observed group, and reports
+// whether any were set.
+func lateInitialize(gp *v1alpha1.GroupParameters, observed cloudian.Group) bool {
+	li := false
+	// Unlike the other fields, an inactive group is not the zero value.
+	if gp.Active == nil {
+		gp.Active = ptr.To(observed.Active)
+		li = true
 	}
+	li = lateinit.Value(&gp.GroupName, observed.GroupName) || li
+	li = lateinit.Ptr(&gp.LDAPEnabled, observed.LDAPEnabled) || li
+	li = lateinit.Ptr(&gp.LDAPGroup, observed.LDAPGroup) || li
+	li = lateinit.Ptr(&gp.LDAPMatchAttribute, observed.LDAPMatchAttribute) || li
+	li = lateinit.Ptr(&gp.LDAPSearch, observed.LDAPSearch) || li
+	li = lateinit.Ptr(&gp.LDAPSearchUserBase, observed.LDAPSearchUserBase) || li
+	li = lateinit.Ptr(&gp.LDAPServerURL, observed.LDAPServerURL) || li
+	li = lateinit.Ptr(&gp.LDAPUserDNTemplate, observed.LDAPUserDNTemplate) || li
+	li = lateinit.Ptr(&gp.RatingPlanID, observed.RatingPlanID) || li
+	return li
 }
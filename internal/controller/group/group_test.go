@@ -18,13 +18,32 @@ package group
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/statnett/provider-cloudian/apis/user/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -72,3 +91,302 @@ func TestObserve(t *testing.T) {
 		})
 	}
 }
+
+func TestLateInitialize(t *testing.T) {
+	observed := cloudian.Group{
+		Active:        false,
+		GroupID:       "foo",
+		GroupName:     "Foo",
+		LDAPEnabled:   true,
+		LDAPServerURL: "ldaps://ldap.example.com",
+		RatingPlanID:  "Default-RP",
+	}
+
+	cases := map[string]struct {
+		reason string
+		params v1alpha1.GroupParameters
+		want   v1alpha1.GroupParameters
+		li     bool
+	}{
+		"Unset": {
+			reason: "Unset parameters should be filled from the observed group, including an inactive state.",
+			params: v1alpha1.GroupParameters{},
+			want: v1alpha1.GroupParameters{
+				Active:        ptr.To(false),
+				GroupName:     "Foo",
+				LDAPEnabled:   ptr.To(true),
+				LDAPServerURL: ptr.To("ldaps://ldap.example.com"),
+				RatingPlanID:  ptr.To("Default-RP"),
+			},
+			li: true,
+		},
+		"Set": {
+			reason: "Set parameters should not be overwritten.",
+			params: v1alpha1.GroupParameters{
+				Active:        ptr.To(true),
+				GroupName:     "Bar",
+				LDAPEnabled:   ptr.To(false),
+				LDAPServerURL: ptr.To("ldaps://other.example.com"),
+				RatingPlanID:  ptr.To("Gold"),
+			},
+			want: v1alpha1.GroupParameters{
+				Active:        ptr.To(true),
+				GroupName:     "Bar",
+				LDAPEnabled:   ptr.To(false),
+				LDAPServerURL: ptr.To("ldaps://other.example.com"),
+				RatingPlanID:  ptr.To("Gold"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			li := lateInitialize(&tc.params, observed)
+			if li != tc.li {
+				t.Errorf("\n%s\nlateInitialize(...): want %t, got %t\n", tc.reason, tc.li, li)
+			}
+			if diff := cmp.Diff(tc.want, tc.params); diff != "" {
+				t.Errorf("\n%s\nlateInitialize(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		requests []string
+		err      error
+		blocked  string
+	}
+
+	cases := map[string]struct {
+		reason string
+		policy v1alpha1.MemberDeletionPolicy
+		users  int
+		want   want
+	}{
+		"EmptyGroup": {
+			reason: "An empty group should be deleted.",
+			want:   want{requests: []string{"GET /user/list", "DELETE /group"}},
+		},
+		"FailIfNotEmpty": {
+			reason: "A group with users should not be deleted, and the users should be listed in a condition.",
+			users:  12,
+			want: want{
+				requests: []string{"GET /user/list"},
+				err:      errors.New(errGroupHasUsers),
+				blocked:  "Group has users: u00, u01, u02, u03, u04, u05, u06, u07, u08, u09 and more",
+			},
+		},
+		"Orphan": {
+			reason: "A group with users should be left in Cloudian.",
+			policy: v1alpha1.MemberDeletionOrphan,
+			users:  2,
+			want:   want{requests: []string{"GET /user/list"}},
+		},
+		"Cascade": {
+			reason: "The credentials, QoS limits and users of a group should be deleted before the group.",
+			policy: v1alpha1.MemberDeletionCascade,
+			users:  1,
+			want: want{requests: []string{
+				"GET /bppolicy/listpolicy",
+				"GET /user/list",
+				"GET /user/credentials/list",
+				"DELETE /user/credentials",
+				"DELETE /qos/limits?region=region1",
+				"DELETE /qos/limits?region=region2",
+				"DELETE /user",
+				"DELETE /qos/limits?region=region1",
+				"DELETE /qos/limits?region=region2",
+				"DELETE /group",
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				request := r.Method + " " + r.URL.Path
+				if region := r.URL.Query().Get("region"); region != "" {
+					request += "?region=" + region
+				}
+				requests = append(requests, request)
+				switch r.URL.Path {
+				case "/bppolicy/listpolicy":
+					json.NewEncoder(w).Encode([]map[string]any{
+						{"policyId": "p1", "region": "region1"},
+						{"policyId": "p2", "region": "region2"},
+						{"policyId": "p3", "region": "region1"},
+					})
+				case "/user/list":
+					limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+					var users []map[string]any
+					for i := range min(tc.users, limit+1) {
+						users = append(users, map[string]any{"groupId": "foo", "userId": fmt.Sprintf("u%02d", i), "active": "true"})
+					}
+					json.NewEncoder(w).Encode(users)
+				case "/user/credentials/list":
+					json.NewEncoder(w).Encode([]map[string]any{{"accessKey": "key", "secretKey": "secret", "active": true}})
+				}
+			}))
+			defer admin.Close()
+
+			cr := &v1alpha1.Group{Spec: v1alpha1.GroupSpec{ForProvider: v1alpha1.GroupParameters{MemberDeletionPolicy: tc.policy}}}
+			meta.SetExternalName(cr, "foo")
+			e := external{cloudianService: cloudian.NewClient(admin.URL, "")}
+
+			_, err := e.Delete(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.requests, requests); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want requests, +got requests:\n%s\n", tc.reason, diff)
+			}
+			if got := cr.GetCondition(v1alpha1.TypeDeletionBlocked).Message; got != tc.want.blocked {
+				t.Errorf("\n%s\ne.Delete(...): DeletionBlocked message = %q, want %q\n", tc.reason, got, tc.want.blocked)
+			}
+		})
+	}
+}
+
+func TestReconcileObserveOnly(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		deleted bool
+	}{
+		"Drift": {
+			reason: "A Group that is only observed should not be updated, even if it differs from Cloudian.",
+		},
+		"Deleted": {
+			reason:  "A Group that is only observed should not be deleted from Cloudian when it is deleted.",
+			deleted: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					requests = append(requests, r.Method+" "+r.URL.Path)
+				}
+				switch r.URL.Path {
+				case "/group":
+					json.NewEncoder(w).Encode(map[string]any{"groupId": "foo", "groupName": "Foo", "active": "true"})
+				case "/user/list":
+					json.NewEncoder(w).Encode([]map[string]any{})
+				}
+			}))
+			defer admin.Close()
+
+			s := runtime.NewScheme()
+			if err := v1alpha1.SchemeBuilder.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			cr := &v1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Finalizers: []string{"finalizer.managedresource.crossplane.io"}},
+				Spec: v1alpha1.GroupSpec{
+					ResourceSpec: xpv1.ResourceSpec{ManagementPolicies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve}, DeletionPolicy: xpv1.DeletionDelete},
+					ForProvider:  v1alpha1.GroupParameters{GroupName: "Bar"},
+				},
+			}
+			meta.SetExternalName(cr, "foo")
+			kube := fakeclient.NewClientBuilder().WithScheme(s).WithObjects(cr).WithStatusSubresource(cr).Build()
+			if tc.deleted {
+				if err := kube.Delete(context.Background(), cr); err != nil {
+					t.Fatal(err)
+				}
+			}
+			e := &external{cloudianService: cloudian.NewClient(admin.URL, "")}
+			r := managed.NewReconciler(&fake.Manager{Client: kube, Scheme: s},
+				resource.ManagedKind(v1alpha1.GroupGroupVersionKind),
+				managed.WithExternalConnecter(managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+					return e, nil
+				})),
+				managed.WithManagementPolicies())
+
+			if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "foo"}}); err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): %v\n", tc.reason, err)
+			}
+			if len(requests) != 0 {
+				t.Errorf("\n%s\nr.Reconcile(...): unexpected requests %v\n", tc.reason, requests)
+			}
+		})
+	}
+}
+
+func TestReconcileDeleteOrphan(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		users  []map[string]any
+		want   []string
+	}{
+		"WithUsers": {
+			reason: "A deleted Group with users should be left in Cloudian, and its managed resource deleted.",
+			users:  []map[string]any{{"userId": "alice"}},
+		},
+		"Empty": {
+			reason: "A deleted empty Group should be deleted from Cloudian, and its managed resource deleted.",
+			want:   []string{"DELETE /group"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			deleted := false
+			admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					requests = append(requests, r.Method+" "+r.URL.Path)
+				}
+				switch {
+				case r.Method == http.MethodDelete && r.URL.Path == "/group":
+					deleted = true
+				case r.URL.Path == "/group" && deleted:
+					w.WriteHeader(http.StatusNoContent)
+				case r.URL.Path == "/group":
+					json.NewEncoder(w).Encode(map[string]any{"groupId": "foo", "groupName": "Foo", "active": "true"})
+				case r.URL.Path == "/user/list":
+					json.NewEncoder(w).Encode(append([]map[string]any{}, tc.users...))
+				}
+			}))
+			defer admin.Close()
+
+			s := runtime.NewScheme()
+			if err := v1alpha1.SchemeBuilder.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			cr := &v1alpha1.Group{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Finalizers: []string{"finalizer.managedresource.crossplane.io"}},
+				Spec: v1alpha1.GroupSpec{
+					ForProvider: v1alpha1.GroupParameters{GroupName: "Foo", MemberDeletionPolicy: v1alpha1.MemberDeletionOrphan},
+				},
+			}
+			meta.SetExternalName(cr, "foo")
+			kube := fakeclient.NewClientBuilder().WithScheme(s).WithObjects(cr).WithStatusSubresource(cr).Build()
+			if err := kube.Delete(context.Background(), cr); err != nil {
+				t.Fatal(err)
+			}
+			e := &external{cloudianService: cloudian.NewClient(admin.URL, "")}
+			r := managed.NewReconciler(&fake.Manager{Client: kube, Scheme: s},
+				resource.ManagedKind(v1alpha1.GroupGroupVersionKind),
+				managed.WithExternalConnecter(managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+					return e, nil
+				})))
+
+			for range 3 {
+				if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "foo"}}); err != nil {
+					t.Fatalf("\n%s\nr.Reconcile(...): %v\n", tc.reason, err)
+				}
+			}
+			if diff := cmp.Diff(tc.want, requests); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want requests, +got requests:\n%s\n", tc.reason, diff)
+			}
+			err := kube.Get(context.Background(), types.NamespacedName{Name: "foo"}, &v1alpha1.Group{})
+			if !kerrors.IsNotFound(err) {
+				t.Errorf("\n%s\nkube.Get(...): want not found, got %v\n", tc.reason, err)
+			}
+		})
+	}
+}
@@ -20,9 +20,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/connection"
@@ -34,32 +32,20 @@ import (
 
 	"github.com/statnett/provider-cloudian/apis/user/v1alpha1"
 	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/controller/connector"
 	qoslimits "github.com/statnett/provider-cloudian/internal/controller/qualityofservicelimits"
 	"github.com/statnett/provider-cloudian/internal/features"
+	"github.com/statnett/provider-cloudian/internal/metrics"
 	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
 )
 
 const (
 	errNotGroupQualityOfServiceLimits = "managed resource is not a GroupQualityOfServiceLimits custom resource"
-	errTrackPCUsage                   = "cannot track ProviderConfig usage"
-	errGetPC                          = "cannot get ProviderConfig"
-	errGetCreds                       = "cannot get credentials"
 
-	errNewClient = "cannot create new Service"
 	errCreateQOS = "cannot create QOS"
 	errDeleteQOS = "cannot delete QOS"
 	errGetQOS    = "cannot get QOS"
-)
-
-var (
-	newCloudianService = func(providerConfig *apisv1alpha1.ProviderConfig, authHeader string) (*cloudian.Client, error) {
-		// FIXME: Don't require InsecureSkipVerify
-		return cloudian.NewClient(
-			providerConfig.Spec.Endpoint,
-			authHeader,
-			cloudian.WithInsecureTLSVerify(true),
-		), nil
-	}
+	errNoGroupID = "groupId is not set, and could not be resolved from groupIdRef or groupIdSelector"
 )
 
 // Setup adds a controller that reconciles GroupQualityOfServiceLimits managed resources.
@@ -71,65 +57,39 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
-	r := managed.NewReconciler(mgr,
-		resource.ManagedKind(v1alpha1.GroupQualityOfServiceLimitsGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
-			kube:         mgr.GetClient(),
-			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newServiceFn: newCloudianService}),
+	var tenants *metrics.Tenants
+	if o.Features.Enabled(features.EnableAlphaTenantMetrics) {
+		tenants = metrics.DefaultTenants
+	}
+
+	conn := connector.New(mgr.GetClient(), newExternalFn(tenants))
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(conn),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		managed.WithConnectionPublishers(cps...))
+		managed.WithConnectionPublishers(cps...),
+	}
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.GroupQualityOfServiceLimitsGroupVersionKind), opts...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1alpha1.GroupQualityOfServiceLimits{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
-}
-
-// A connector is expected to produce an ExternalClient when its Connect method
-// is called.
-type connector struct {
-	kube         client.Client
-	usage        resource.Tracker
-	newServiceFn func(providerConfig *apisv1alpha1.ProviderConfig, authHeader string) (*cloudian.Client, error)
+		Complete(ratelimiter.NewReconciler(name, conn.Reconciler(r, o.PollInterval), o.GlobalRateLimiter))
 }
 
-// Connect typically produces an ExternalClient by:
-// 1. Tracking that the managed resource is using a ProviderConfig.
-// 2. Getting the managed resource's ProviderConfig.
-// 3. Getting the credentials specified by the ProviderConfig.
-// 4. Using the credentials to form a client.
-func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mg.(*v1alpha1.GroupQualityOfServiceLimits)
-	if !ok {
-		return nil, errors.New(errNotGroupQualityOfServiceLimits)
-	}
-
-	if err := c.usage.Track(ctx, mg); err != nil {
-		return nil, errors.Wrap(err, errTrackPCUsage)
-	}
-
-	pc := &apisv1alpha1.ProviderConfig{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
-		return nil, errors.Wrap(err, errGetPC)
-	}
-
-	cd := pc.Spec.AuthHeader
-	authHeader, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
-	if err != nil {
-		return nil, errors.Wrap(err, errGetCreds)
-	}
-
-	svc, err := c.newServiceFn(pc, string(authHeader))
-	if err != nil {
-		return nil, errors.Wrap(err, errNewClient)
+// newExternalFn returns a function that produces an ExternalClient from a
+// Cloudian client, which records observed limits in tenants.
+func newExternalFn(tenants *metrics.Tenants) connector.NewExternalFn {
+	return func(svc *cloudian.Client, _ *apisv1alpha1.ProviderConfig) managed.ExternalClient {
+		return &external{cloudianService: svc, tenants: tenants}
 	}
-
-	return &external{cloudianService: svc}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
@@ -138,6 +98,7 @@ type external struct {
 	// A 'client' used to connect to the external resource API. In practice this
 	// would be something like an AWS SDK client.
 	cloudianService *cloudian.Client
+	tenants         *metrics.Tenants
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -148,7 +109,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	groupID := cr.Spec.ForProvider.GroupID
 	if groupID == "" {
-		return managed.ExternalObservation{}, nil
+		return managed.ExternalObservation{}, errors.New(errNoGroupID)
 	}
 
 	guid := cloudian.GroupUserID{
@@ -158,12 +119,16 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	qos, err := c.cloudianService.GetQOS(ctx, guid, cr.Spec.ForProvider.Region)
 
 	if errors.Is(err, cloudian.ErrNotFound) {
+		c.tenants.DeleteLimits(guid)
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errGetQOS)
 	}
+	c.tenants.SetLimits(guid, *qos)
 
+	cr.Status.AtProvider.QOS = qoslimits.FromCloudianQOS(*qos)
+	lateInitialized := qoslimits.LateInitialize(&cr.Spec.ForProvider.QOS, cr.Status.AtProvider.QOS)
 	cr.SetConditions(xpv1.Available())
 
 	expected, err := qoslimits.ToCloudianQOS(cr.Spec.ForProvider.QOS)
@@ -177,6 +142,10 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		// (re)create the resource, or that it has successfully been deleted.
 		ResourceExists: true,
 
+		// Return true when unset spec fields were filled from the external
+		// resource, so that the managed resource reconciler persists them.
+		ResourceLateInitialized: lateInitialized,
+
 		// Return false when the external resource exists, but it not up to date
 		// with the desired managed resource state. This lets the managed
 		// resource reconciler know that it needs to call Update.
@@ -255,8 +224,9 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	}
 	err := c.cloudianService.DeleteQOS(ctx, guid, cr.Spec.ForProvider.Region)
 	if err != nil && !errors.Is(err, cloudian.ErrNotFound) {
-		return managed.ExternalDelete{}, errors.Wrap(err, errGetCreds)
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteQOS)
 	}
+	c.tenants.DeleteLimits(guid)
 
 	return managed.ExternalDelete{}, nil
 }
@@ -0,0 +1,41 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lateinit fills unset spec fields of managed resources from the
+// observed external resource.
+package lateinit
+
+// Ptr sets an unset optional field to the observed value, unless the observed
+// value is the zero value. It reports whether the field was set.
+func Ptr[T comparable](field **T, observed T) bool {
+	var zero T
+	if *field != nil || observed == zero {
+		return false
+	}
+	*field = &observed
+	return true
+}
+
+// Value sets a field holding the zero value to the observed value. It reports
+// whether the field was set.
+func Value[T comparable](field *T, observed T) bool {
+	var zero T
+	if *field != zero || observed == zero {
+		return false
+	}
+	*field = observed
+	return true
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lateinit
+
+import (
+	"testing"
+
+	"k8s.io/utils/ptr"
+)
+
+func TestPtr(t *testing.T) {
+	var unset *string
+	if !Ptr(&unset, "observed") || *unset != "observed" {
+		t.Errorf("Ptr(...): expected unset field to be set, got %v", unset)
+	}
+
+	set := ptr.To("desired")
+	if Ptr(&set, "observed") || *set != "desired" {
+		t.Errorf("Ptr(...): expected set field to be kept, got %q", *set)
+	}
+
+	var zero *string
+	if Ptr(&zero, "") || zero != nil {
+		t.Errorf("Ptr(...): expected zero observed value to be ignored, got %v", zero)
+	}
+}
+
+func TestValue(t *testing.T) {
+	unset := ""
+	if !Value(&unset, "observed") || unset != "observed" {
+		t.Errorf("Value(...): expected unset field to be set, got %q", unset)
+	}
+
+	set := "desired"
+	if Value(&set, "observed") || set != "desired" {
+		t.Errorf("Value(...): expected set field to be kept, got %q", set)
+	}
+}
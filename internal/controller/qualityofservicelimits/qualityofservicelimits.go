@@ -47,3 +47,57 @@ func ToCloudianLimits(limits *v1alpha1.QualityOfServiceLimits) (cloudian.Quality
 
 	return qosl, nil
 }
+
+// FromCloudianQOS converts observed Cloudian limits to their API representation.
+func FromCloudianQOS(qos cloudian.QualityOfService) v1alpha1.QOS {
+	return v1alpha1.QOS{
+		Warning: FromCloudianLimits(qos.Warning),
+		Hard:    FromCloudianLimits(qos.Hard),
+	}
+}
+
+// FromCloudianLimits converts observed Cloudian limits to their API
+// representation. Unlimited values are left unset, and nil is returned if
+// there are no limits.
+func FromCloudianLimits(qosl cloudian.QualityOfServiceLimits) *v1alpha1.QualityOfServiceLimits {
+	quantity := func(kib *int64) *v1alpha1.Quantity {
+		if kib == nil || *kib < 0 {
+			return nil
+		}
+		return ptr.To(v1alpha1.QuantityFromKiB(*kib))
+	}
+	count := func(n *int64) *uint32 {
+		if n == nil || *n < 0 {
+			return nil
+		}
+		return ptr.To(uint32(*n))
+	}
+
+	limits := v1alpha1.QualityOfServiceLimits{
+		StorageQuotaBytes:   quantity(qosl.StorageQuotaKiBs),
+		StorageQuotaCount:   count(qosl.StorageQuotaCount),
+		RequestsPerMin:      count(qosl.RequestsPerMin),
+		InboundBytesPerMin:  quantity(qosl.InboundKiBsPerMin),
+		OutboundBytesPerMin: quantity(qosl.OutboundKiBsPerMin),
+	}
+	if limits == (v1alpha1.QualityOfServiceLimits{}) {
+		return nil
+	}
+	return &limits
+}
+
+// LateInitialize fills unset warning and hard limits from the observed limits,
+// and reports whether any were set. Individual limits are not filled, since
+// an unset limit within a set of limits means unlimited.
+func LateInitialize(qos *v1alpha1.QOS, observed v1alpha1.QOS) bool {
+	li := false
+	if qos.Warning == nil && observed.Warning != nil {
+		qos.Warning = observed.Warning
+		li = true
+	}
+	if qos.Hard == nil && observed.Hard != nil {
+		qos.Hard = observed.Hard
+		li = true
+	}
+	return li
+}
@@ -0,0 +1,77 @@
+package qualityofservicelimits
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+
+	"github.com/statnett/provider-cloudian/apis/user/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+func TestFromCloudianLimits(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		limits cloudian.QualityOfServiceLimits
+		want   *v1alpha1.QualityOfServiceLimits
+	}{
+		"Unlimited": {
+			reason: "No limits should be represented by nil.",
+			limits: cloudian.QualityOfServiceLimits{RequestsPerMin: ptr.To(int64(-1))},
+		},
+		"Limits": {
+			reason: "Limits in KiB should be formatted with the largest exact suffix.",
+			limits: cloudian.QualityOfServiceLimits{
+				StorageQuotaKiBs:   ptr.To(int64(2 * 1024 * 1024 * 1024)),
+				StorageQuotaCount:  ptr.To(int64(50000)),
+				RequestsPerMin:     ptr.To(int64(50)),
+				InboundKiBsPerMin:  ptr.To(int64(5 * 1024)),
+				OutboundKiBsPerMin: ptr.To(int64(1536)),
+			},
+			want: &v1alpha1.QualityOfServiceLimits{
+				StorageQuotaBytes:   ptr.To(v1alpha1.Quantity("2Ti")),
+				StorageQuotaCount:   ptr.To(uint32(50000)),
+				RequestsPerMin:      ptr.To(uint32(50)),
+				InboundBytesPerMin:  ptr.To(v1alpha1.Quantity("5Mi")),
+				OutboundBytesPerMin: ptr.To(v1alpha1.Quantity("1536Ki")),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := FromCloudianLimits(tc.limits)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nFromCloudianLimits(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if got == nil {
+				return
+			}
+			// Converting back should give the original limits.
+			back, err := ToCloudianLimits(got)
+			if err != nil {
+				t.Fatalf("ToCloudianLimits(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.limits, back); diff != "" {
+				t.Errorf("\n%s\nToCloudianLimits(FromCloudianLimits(...)): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitialize(t *testing.T) {
+	observed := v1alpha1.QOS{
+		Warning: &v1alpha1.QualityOfServiceLimits{RequestsPerMin: ptr.To(uint32(10))},
+		Hard:    &v1alpha1.QualityOfServiceLimits{RequestsPerMin: ptr.To(uint32(20))},
+	}
+	qos := v1alpha1.QOS{Hard: &v1alpha1.QualityOfServiceLimits{}}
+
+	if !LateInitialize(&qos, observed) {
+		t.Error("LateInitialize(...): expected unset warning limits to be late initialized")
+	}
+	want := v1alpha1.QOS{Warning: observed.Warning, Hard: &v1alpha1.QualityOfServiceLimits{}}
+	if diff := cmp.Diff(want, qos); diff != "" {
+		t.Errorf("LateInitialize(...): -want, +got:\n%s", diff)
+	}
+}
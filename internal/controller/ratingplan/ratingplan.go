@@ -0,0 +1,229 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratingplan
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/statnett/provider-cloudian/apis/user/v1alpha1"
+	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/controller/connector"
+	"github.com/statnett/provider-cloudian/internal/features"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+const (
+	errNotRatingPlan = "managed resource is not a RatingPlan custom resource"
+
+	errCreateRatingPlan = "cannot create RatingPlan"
+	errDeleteRatingPlan = "cannot delete RatingPlan"
+	errGetRatingPlan    = "cannot get RatingPlan"
+	errUpdateRatingPlan = "cannot update RatingPlan"
+	errRatingPlanExists = "RatingPlan already exists in Cloudian, and is observed on the next reconcile"
+	errRatingPlanInUse  = "cannot delete RatingPlan while it is assigned to groups or users"
+)
+
+// Setup adds a controller that reconciles RatingPlan managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.RatingPlanGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	conn := connector.New(mgr.GetClient(), newExternal)
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(conn),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...),
+	}
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.RatingPlanGroupVersionKind), opts...)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.RatingPlan{}).
+		Complete(ratelimiter.NewReconciler(name, conn.Reconciler(r, o.PollInterval), o.GlobalRateLimiter))
+}
+
+// newExternal produces an ExternalClient from a Cloudian client.
+func newExternal(svc *cloudian.Client, _ *apisv1alpha1.ProviderConfig) managed.ExternalClient {
+	return &external{cloudianService: svc}
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes a
+// rating plan to ensure it reflects the managed resource's desired state.
+type external struct {
+	cloudianService *cloudian.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RatingPlan)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRatingPlan)
+	}
+
+	externalName := meta.GetExternalName(cr)
+	if externalName == "" {
+		return managed.ExternalObservation{}, nil
+	}
+
+	observedPlan, err := c.cloudianService.GetRatingPlan(ctx, externalName)
+	if errors.Is(err, cloudian.ErrNotFound) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetRatingPlan)
+	}
+
+	cr.Status.AtProvider = v1alpha1.RatingPlanObservation{
+		Name:     observedPlan.Name,
+		Currency: observedPlan.Currency,
+	}
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Return false when the external resource exists, but it not up to date
+		// with the desired managed resource state. This lets the managed
+		// resource reconciler know that it needs to call Update.
+		ResourceUpToDate: isUpToDate(externalName, cr.Spec.ForProvider, *observedPlan),
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RatingPlan)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRatingPlan)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	err := c.cloudianService.CreateRatingPlan(ctx, newCloudianRatingPlan(meta.GetExternalName(mg), cr.Spec.ForProvider))
+	if cloudian.IsConflict(err) {
+		return managed.ExternalCreation{}, errors.Wrap(err, errRatingPlanExists)
+	}
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateRatingPlan)
+	}
+
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RatingPlan)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRatingPlan)
+	}
+
+	if err := c.cloudianService.UpdateRatingPlan(ctx, newCloudianRatingPlan(meta.GetExternalName(mg), cr.Spec.ForProvider)); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateRatingPlan)
+	}
+
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*v1alpha1.RatingPlan)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotRatingPlan)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	err := c.cloudianService.DeleteRatingPlan(ctx, meta.GetExternalName(mg))
+	if cloudian.IsConflict(err) {
+		return managed.ExternalDelete{}, errors.Wrap(err, errRatingPlanInUse)
+	}
+	if err != nil && !errors.Is(err, cloudian.ErrNotFound) {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteRatingPlan)
+	}
+
+	return managed.ExternalDelete{}, nil
+}
+
+func (c *external) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+func isUpToDate(id string, desired v1alpha1.RatingPlanParameters, observed cloudian.RatingPlan) bool {
+	return cmp.Equal(newCloudianRatingPlan(id, desired), observed, cmpopts.EquateEmpty())
+}
+
+func newCloudianRatingPlan(id string, p v1alpha1.RatingPlanParameters) cloudian.RatingPlan {
+	return cloudian.RatingPlan{
+		ID:              id,
+		Name:            p.Name,
+		Currency:        p.Currency,
+		Storage:         newCloudianRatingTiers(p.Storage),
+		DataTransferIn:  newCloudianRatingTiers(p.DataTransferIn),
+		DataTransferOut: newCloudianRatingTiers(p.DataTransferOut),
+		GetRequests:     newCloudianRatingTiers(p.GetRequests),
+		PutRequests:     newCloudianRatingTiers(p.PutRequests),
+		DeleteRequests:  newCloudianRatingTiers(p.DeleteRequests),
+	}
+}
+
+func newCloudianRatingTiers(tiers []v1alpha1.RatingTier) []cloudian.RatingTier {
+	if len(tiers) == 0 {
+		return nil
+	}
+	retVal := make([]cloudian.RatingTier, 0, len(tiers))
+	for _, t := range tiers {
+		retVal = append(retVal, cloudian.RatingTier{UpTo: ptr.Deref(t.UpTo, 0), Price: t.Price})
+	}
+	return retVal
+}
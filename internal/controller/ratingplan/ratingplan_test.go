@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratingplan
+
+import (
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/statnett/provider-cloudian/apis/user/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+func TestIsUpToDate(t *testing.T) {
+	desired := v1alpha1.RatingPlanParameters{
+		Name:     "Gold",
+		Currency: "USD",
+		Storage: []v1alpha1.RatingTier{
+			{UpTo: ptr.To[int64](1000), Price: "0.05"},
+			{Price: "0.04"},
+		},
+		GetRequests: []v1alpha1.RatingTier{{Price: "0.01"}},
+	}
+	observed := cloudian.RatingPlan{
+		ID:       "gold",
+		Name:     "Gold",
+		Currency: "USD",
+		Storage: []cloudian.RatingTier{
+			{UpTo: 1000, Price: "0.05"},
+			{UpTo: 0, Price: "0.04"},
+		},
+		DataTransferIn: []cloudian.RatingTier{},
+		GetRequests:    []cloudian.RatingTier{{Price: "0.01"}},
+	}
+
+	cases := map[string]struct {
+		observed func(p cloudian.RatingPlan) cloudian.RatingPlan
+		want     bool
+	}{
+		"UpToDate": {
+			observed: func(p cloudian.RatingPlan) cloudian.RatingPlan { return p },
+			want:     true,
+		},
+		"NameDrift": {
+			observed: func(p cloudian.RatingPlan) cloudian.RatingPlan {
+				p.Name = "Silver"
+				return p
+			},
+			want: false,
+		},
+		"PriceDrift": {
+			observed: func(p cloudian.RatingPlan) cloudian.RatingPlan {
+				p.Storage = []cloudian.RatingTier{{UpTo: 1000, Price: "0.06"}, {Price: "0.04"}}
+				return p
+			},
+			want: false,
+		},
+		"ExtraRating": {
+			observed: func(p cloudian.RatingPlan) cloudian.RatingPlan {
+				p.PutRequests = []cloudian.RatingTier{{Price: "0.02"}}
+				return p
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := isUpToDate("gold", desired, tc.observed(observed)); got != tc.want {
+				t.Errorf("isUpToDate() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
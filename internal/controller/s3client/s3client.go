@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package s3client builds clients of the S3 API of Cloudian, which act as the
+// user owning an access key.
+package s3client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	s3v1alpha1 "github.com/statnett/provider-cloudian/apis/s3/v1alpha1"
+	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+	"github.com/statnett/provider-cloudian/internal/sdk/s3"
+)
+
+const (
+	errNoS3Endpoint  = "ProviderConfig has no S3 endpoint"
+	errGetCreds      = "cannot get credentials of access key"
+	errListCreds     = "cannot list credentials of user"
+	errNoActiveCreds = "user has no active access key"
+)
+
+// New returns a client of the S3 endpoint of a ProviderConfig. It sends
+// requests with the access key of creds, or else with the first active access
+// key of the user of creds. The access key is looked up with svc, whose S3
+// HTTP client is reused.
+func New(ctx context.Context, svc *cloudian.Client, cfg *apisv1alpha1.S3Config, creds s3v1alpha1.S3Credentials) (*s3.Client, error) {
+	if cfg == nil || cfg.Endpoint == "" {
+		return nil, errors.New(errNoS3Endpoint)
+	}
+
+	key, err := securityInfo(ctx, svc, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	return s3.NewClient(cfg.Endpoint, cfg.Region, key.AccessKey, key.SecretKey, svc.S3HTTPClient()), nil
+}
+
+func securityInfo(ctx context.Context, svc *cloudian.Client, creds s3v1alpha1.S3Credentials) (*cloudian.SecurityInfo, error) {
+	if creds.AccessKeyID != "" {
+		key, err := svc.GetUserCredentials(ctx, creds.AccessKeyID)
+		if err != nil {
+			return nil, errors.Wrap(err, errGetCreds)
+		}
+		return key, nil
+	}
+
+	keys, err := svc.ListUserCredentials(ctx, cloudian.GroupUserID{GroupID: creds.GroupID, UserID: creds.UserID})
+	if err != nil {
+		return nil, errors.Wrap(err, errListCreds)
+	}
+	for _, key := range keys {
+		if key.Active {
+			return &key, nil
+		}
+	}
+	return nil, errors.New(errNoActiveCreds)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	s3v1alpha1 "github.com/statnett/provider-cloudian/apis/s3/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+func TestSecurityInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user/credentials":
+			json.NewEncoder(w).Encode(map[string]any{"accessKey": r.URL.Query().Get("accessKey"), "secretKey": "secret", "active": true})
+		case "/user/credentials/list":
+			if r.URL.Query().Get("userId") != "alice" {
+				json.NewEncoder(w).Encode([]any{})
+				return
+			}
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"accessKey": "inactive", "secretKey": "secret1", "active": false},
+				{"accessKey": "active", "secretKey": "secret2", "active": true},
+			})
+		}
+	}))
+	defer server.Close()
+	svc := cloudian.NewClient(server.URL, "")
+
+	cases := map[string]struct {
+		creds   s3v1alpha1.S3Credentials
+		want    string
+		wantErr bool
+	}{
+		"AccessKey": {
+			creds: s3v1alpha1.S3Credentials{AccessKeyID: "key"},
+			want:  "key",
+		},
+		"FirstActiveKeyOfUser": {
+			creds: s3v1alpha1.S3Credentials{GroupID: "QA", UserID: "alice"},
+			want:  "active",
+		},
+		"UserWithoutKeys": {
+			creds:   s3v1alpha1.S3Credentials{GroupID: "QA", UserID: "bob"},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := securityInfo(context.Background(), svc, tc.creds)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("securityInfo() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got.AccessKey); diff != "" {
+				t.Errorf("securityInfo() access key mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
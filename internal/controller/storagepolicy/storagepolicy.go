@@ -0,0 +1,249 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagepolicy
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/statnett/provider-cloudian/apis/storage/v1alpha1"
+	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/controller/connector"
+	"github.com/statnett/provider-cloudian/internal/features"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+const (
+	errNotStoragePolicy = "managed resource is not a StoragePolicy custom resource"
+
+	errCreateStoragePolicy = "cannot create StoragePolicy"
+	errDeleteStoragePolicy = "cannot delete StoragePolicy"
+	errGetStoragePolicy    = "cannot get StoragePolicy"
+	errUpdateStoragePolicy = "cannot update StoragePolicy"
+	errStoragePolicyInUse  = "cannot delete StoragePolicy while buckets are bound to it"
+)
+
+// Setup adds a controller that reconciles StoragePolicy managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.StoragePolicyGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	conn := connector.New(mgr.GetClient(), newExternal)
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(conn),
+		// The external name is the policy ID assigned by Cloudian on Create,
+		// rather than the name of the managed resource.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...),
+	}
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.StoragePolicyGroupVersionKind), opts...)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.StoragePolicy{}).
+		Complete(ratelimiter.NewReconciler(name, conn.Reconciler(r, o.PollInterval), o.GlobalRateLimiter))
+}
+
+// newExternal produces an ExternalClient from a Cloudian client.
+func newExternal(svc *cloudian.Client, _ *apisv1alpha1.ProviderConfig) managed.ExternalClient {
+	return &external{cloudianService: svc}
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	cloudianService *cloudian.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.StoragePolicy)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotStoragePolicy)
+	}
+
+	policyID := meta.GetExternalName(cr)
+	if policyID == "" {
+		return managed.ExternalObservation{}, nil
+	}
+
+	observed, err := c.cloudianService.GetStoragePolicy(ctx, policyID)
+	if errors.Is(err, cloudian.ErrNotFound) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetStoragePolicy)
+	}
+
+	cr.Status.AtProvider = v1alpha1.StoragePolicyObservation{
+		PolicyID: observed.PolicyID,
+		Status:   observed.Status,
+		Default:  observed.Default,
+	}
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Return false when the external resource exists, but it not up to date
+		// with the desired managed resource state. This lets the managed
+		// resource reconciler know that it needs to call Update.
+		ResourceUpToDate: isUpToDate(cr.Spec.ForProvider, *observed),
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.StoragePolicy)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotStoragePolicy)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	policyID, err := c.cloudianService.CreateStoragePolicy(ctx, newCloudianStoragePolicy(cr.Spec.ForProvider))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateStoragePolicy)
+	}
+	meta.SetExternalName(cr, policyID)
+
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.StoragePolicy)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotStoragePolicy)
+	}
+
+	// Update the observed policy, so that the default policy is kept default.
+	policy, err := c.cloudianService.GetStoragePolicy(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateStoragePolicy)
+	}
+	desired := newCloudianStoragePolicy(cr.Spec.ForProvider)
+	policy.PolicyName = desired.PolicyName
+	policy.Description = desired.Description
+	policy.Compression = desired.Compression
+	policy.Encryption = desired.Encryption
+	policy.ReadConsistency = desired.ReadConsistency
+	policy.WriteConsistency = desired.WriteConsistency
+	policy.Groups = desired.Groups
+
+	if err := c.cloudianService.UpdateStoragePolicy(ctx, *policy); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateStoragePolicy)
+	}
+
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*v1alpha1.StoragePolicy)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotStoragePolicy)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	err := c.cloudianService.DeleteStoragePolicy(ctx, meta.GetExternalName(cr))
+	if cloudian.IsConflict(err) {
+		return managed.ExternalDelete{}, errors.Wrap(err, errStoragePolicyInUse)
+	}
+	if err != nil && !errors.Is(err, cloudian.ErrNotFound) {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteStoragePolicy)
+	}
+
+	return managed.ExternalDelete{}, nil
+}
+
+func (c *external) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// isUpToDate compares the parameters of a storage policy that can be updated.
+// The data protection scheme, data centers and region cannot be changed.
+func isUpToDate(desired v1alpha1.StoragePolicyParameters, observed cloudian.StoragePolicy) bool {
+	d := newCloudianStoragePolicy(desired)
+	return d.PolicyName == observed.PolicyName &&
+		d.Description == observed.Description &&
+		d.Compression == observed.Compression &&
+		d.Encryption == observed.Encryption &&
+		d.ReadConsistency == observed.ReadConsistency &&
+		d.WriteConsistency == observed.WriteConsistency &&
+		cmp.Equal(d.Groups, observed.Groups, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
+
+func newCloudianStoragePolicy(p v1alpha1.StoragePolicyParameters) cloudian.StoragePolicy {
+	policy := cloudian.StoragePolicy{
+		PolicyName:       p.PolicyName,
+		Description:      ptr.Deref(p.Description, ""),
+		Region:           p.Region,
+		Scheme:           cloudian.StorageScheme(p.Scheme),
+		Replicas:         int(ptr.Deref(p.Replicas, 0)),
+		DataCenters:      p.DataCenters,
+		Compression:      p.Compression,
+		Encryption:       p.Encryption,
+		ReadConsistency:  p.ReadConsistency,
+		WriteConsistency: p.WriteConsistency,
+		Groups:           p.Groups,
+	}
+	if ec := p.ErasureCoding; ec != nil {
+		policy.ECDataFragments = int(ec.DataFragments)
+		policy.ECParityFragments = int(ec.ParityFragments)
+	}
+	return policy
+}
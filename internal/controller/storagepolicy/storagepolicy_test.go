@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagepolicy
+
+import (
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/statnett/provider-cloudian/apis/storage/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+func TestIsUpToDate(t *testing.T) {
+	desired := v1alpha1.StoragePolicyParameters{
+		PolicyName:       "Replicated",
+		Description:      ptr.To("Three replicas"),
+		Scheme:           "REPLICATION",
+		Replicas:         ptr.To[int32](3),
+		DataCenters:      []string{"DC1"},
+		Compression:      "NONE",
+		Encryption:       "NONE",
+		ReadConsistency:  "QUORUM",
+		WriteConsistency: "QUORUM",
+		Groups:           []string{"QA", "Prod"},
+	}
+	observed := cloudian.StoragePolicy{
+		PolicyID:         "abc",
+		PolicyName:       "Replicated",
+		Description:      "Three replicas",
+		Scheme:           cloudian.StorageSchemeReplication,
+		Replicas:         3,
+		DataCenters:      []string{"DC1"},
+		Compression:      "NONE",
+		Encryption:       "NONE",
+		ReadConsistency:  "QUORUM",
+		WriteConsistency: "QUORUM",
+		Groups:           []string{"Prod", "QA"},
+		Default:          true,
+		Status:           "ACTIVE",
+	}
+
+	cases := map[string]struct {
+		observed func(p cloudian.StoragePolicy) cloudian.StoragePolicy
+		want     bool
+	}{
+		"UpToDate": {
+			observed: func(p cloudian.StoragePolicy) cloudian.StoragePolicy { return p },
+			want:     true,
+		},
+		"ImmutableSchemeIgnored": {
+			observed: func(p cloudian.StoragePolicy) cloudian.StoragePolicy {
+				p.Replicas = 2
+				p.DataCenters = []string{"DC2"}
+				return p
+			},
+			want: true,
+		},
+		"CompressionDrift": {
+			observed: func(p cloudian.StoragePolicy) cloudian.StoragePolicy {
+				p.Compression = "LZ4"
+				return p
+			},
+			want: false,
+		},
+		"ConsistencyDrift": {
+			observed: func(p cloudian.StoragePolicy) cloudian.StoragePolicy {
+				p.WriteConsistency = "ALL"
+				return p
+			},
+			want: false,
+		},
+		"GroupsDrift": {
+			observed: func(p cloudian.StoragePolicy) cloudian.StoragePolicy {
+				p.Groups = nil
+				return p
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := isUpToDate(desired, tc.observed(observed)); got != tc.want {
+				t.Errorf("isUpToDate() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
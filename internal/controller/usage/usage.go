@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package usage observes the usage of groups and users at the usage poll
+// interval of their ProviderConfig.
+package usage
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/statnett/provider-cloudian/apis/user/v1alpha1"
+	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/metrics"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+const errGetUsage = "cannot get usage"
+
+// Defaults of an unset UsageConfig.
+const (
+	DefaultPollInterval = 15 * time.Minute
+	DefaultWindow       = 24 * time.Hour
+)
+
+// Observe returns the usage of a group or user. The last observed usage is
+// returned until it is older than the poll interval, and nil is returned if
+// usage is not observed.
+func Observe(ctx context.Context, svc *cloudian.Client, config *apisv1alpha1.UsageConfig, guid cloudian.GroupUserID, last *v1alpha1.UsageObservation) (*v1alpha1.UsageObservation, error) {
+	pollInterval, window := settings(config)
+	if pollInterval <= 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	if !stale(last, pollInterval, now) {
+		return last, nil
+	}
+
+	usage, err := svc.GetUsage(ctx, guid, now.Add(-window))
+	if errors.Is(err, cloudian.ErrNotFound) {
+		// Cloudian has no usage of groups and users that have not stored
+		// any data yet.
+		usage, err = &cloudian.Usage{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errGetUsage)
+	}
+
+	return &v1alpha1.UsageObservation{
+		StorageBytes: usage.StorageBytes,
+		ObjectCount:  usage.ObjectCount,
+		Requests:     usage.Requests,
+		BytesIn:      usage.BytesIn,
+		BytesOut:     usage.BytesOut,
+		LastUpdated:  metav1.NewTime(now),
+	}, nil
+}
+
+// Update observes the usage of a group or user for the status of mg. Usage is
+// optional, so it never fails a reconcile: the last usage is kept if usage
+// cannot be observed, which is reported by a condition of mg instead. Usage of
+// a group or user that is being deleted is not observed.
+func Update(ctx context.Context, svc *cloudian.Client, config *apisv1alpha1.UsageConfig, mg resource.Managed, guid cloudian.GroupUserID, last *v1alpha1.UsageObservation) *v1alpha1.UsageObservation {
+	if meta.WasDeleted(mg) {
+		return last
+	}
+	u, err := Observe(ctx, svc, config, guid, last)
+	if err != nil {
+		mg.SetConditions(v1alpha1.UsageError(err))
+		return last
+	}
+	if u != nil {
+		mg.SetConditions(v1alpha1.UsageObserved())
+	}
+	return u
+}
+
+// Record publishes the observed usage of a group or user to tenants, or
+// forgets it if usage is not observed.
+func Record(tenants *metrics.Tenants, guid cloudian.GroupUserID, u *v1alpha1.UsageObservation) {
+	if u == nil {
+		tenants.DeleteUsage(guid)
+		return
+	}
+	tenants.SetUsage(guid, u.StorageBytes, u.ObjectCount)
+}
+
+// settings returns the poll interval and window of a UsageConfig.
+func settings(config *apisv1alpha1.UsageConfig) (time.Duration, time.Duration) {
+	pollInterval, window := DefaultPollInterval, DefaultWindow
+	if config == nil {
+		return pollInterval, window
+	}
+	if config.PollInterval != nil {
+		pollInterval = config.PollInterval.Duration
+	}
+	if config.Window != nil {
+		window = config.Window.Duration
+	}
+	return pollInterval, window
+}
+
+// stale reports whether usage should be observed anew.
+func stale(last *v1alpha1.UsageObservation, pollInterval time.Duration, now time.Time) bool {
+	return last == nil || now.Sub(last.LastUpdated.Time) >= pollInterval
+}
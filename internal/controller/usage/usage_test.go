@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/statnett/provider-cloudian/apis/user/v1alpha1"
+	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+func TestObserve(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/system/bytecount":
+			_ = json.NewEncoder(w).Encode(2048)
+		case "/system/objectcount":
+			_ = json.NewEncoder(w).Encode(2)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	svc := cloudian.NewClient(server.URL, "")
+	guid := cloudian.GroupUserID{GroupID: "QA", UserID: "*"}
+
+	fresh := &v1alpha1.UsageObservation{StorageBytes: 1, LastUpdated: metav1.NewTime(time.Now().Add(-time.Minute))}
+	old := &v1alpha1.UsageObservation{StorageBytes: 1, LastUpdated: metav1.NewTime(time.Now().Add(-time.Hour))}
+
+	cases := map[string]struct {
+		config   *apisv1alpha1.UsageConfig
+		last     *v1alpha1.UsageObservation
+		want     *v1alpha1.UsageObservation
+		requests bool
+	}{
+		"FirstObservation": {
+			want:     &v1alpha1.UsageObservation{StorageBytes: 2048, ObjectCount: 2},
+			requests: true,
+		},
+		"Fresh": {
+			last: fresh,
+			want: fresh,
+		},
+		"Stale": {
+			last:     old,
+			want:     &v1alpha1.UsageObservation{StorageBytes: 2048, ObjectCount: 2},
+			requests: true,
+		},
+		"Disabled": {
+			config: &apisv1alpha1.UsageConfig{PollInterval: &metav1.Duration{}},
+			last:   fresh,
+			want:   nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			requests = 0
+			got, err := Observe(context.Background(), svc, tc.config, guid, tc.last)
+			if err != nil {
+				t.Fatalf("Observe() error = %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(v1alpha1.UsageObservation{}, "LastUpdated")); diff != "" {
+				t.Errorf("Observe() mismatch (-want +got):\n%s", diff)
+			}
+			if (requests > 0) != tc.requests {
+				t.Errorf("Observe() made %d requests, want requests: %v", requests, tc.requests)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	last := &v1alpha1.UsageObservation{StorageBytes: 1, LastUpdated: metav1.NewTime(time.Now().Add(-time.Hour))}
+
+	type want struct {
+		usage     *v1alpha1.UsageObservation
+		condition xpv1.ConditionReason
+		requests  bool
+	}
+
+	cases := map[string]struct {
+		reason  string
+		status  int
+		deleted bool
+		want    want
+	}{
+		"Observed": {
+			reason: "Usage should be observed, and reported by a condition.",
+			status: http.StatusOK,
+			want: want{
+				usage:     &v1alpha1.UsageObservation{StorageBytes: 2048},
+				condition: v1alpha1.ReasonUsageObserved,
+				requests:  true,
+			},
+		},
+		"NoData": {
+			reason: "A user that has not stored any data should have no usage.",
+			status: http.StatusNoContent,
+			want: want{
+				usage:     &v1alpha1.UsageObservation{},
+				condition: v1alpha1.ReasonUsageObserved,
+				requests:  true,
+			},
+		},
+		"Error": {
+			reason: "Usage that cannot be observed should keep the last usage, and be reported by a condition.",
+			status: http.StatusInternalServerError,
+			want: want{
+				usage:     last,
+				condition: v1alpha1.ReasonUsageError,
+				requests:  true,
+			},
+		},
+		"Deleted": {
+			reason:  "Usage of a user that is being deleted should not be observed.",
+			status:  http.StatusOK,
+			deleted: true,
+			want:    want{usage: last},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.URL.Path != "/system/bytecount" && r.URL.Path != "/system/objectcount" {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				w.WriteHeader(tc.status)
+				switch {
+				case tc.status != http.StatusOK:
+				case r.URL.Path == "/system/bytecount":
+					_ = json.NewEncoder(w).Encode(2048)
+				default:
+					_ = json.NewEncoder(w).Encode(0)
+				}
+			}))
+			defer server.Close()
+
+			cr := &v1alpha1.User{}
+			if tc.deleted {
+				cr.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+			}
+			svc := cloudian.NewClient(server.URL, "", cloudian.WithRetries(0, 0, 0))
+			got := Update(context.Background(), svc, nil, cr, cloudian.GroupUserID{GroupID: "QA", UserID: "bar"}, last)
+
+			if diff := cmp.Diff(tc.want.usage, got, cmpopts.IgnoreFields(v1alpha1.UsageObservation{}, "LastUpdated")); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if got := cr.GetCondition(v1alpha1.TypeUsageObserved).Reason; got != tc.want.condition {
+				t.Errorf("\n%s\nUpdate(...): condition reason = %q, want %q\n", tc.reason, got, tc.want.condition)
+			}
+			if (requests > 0) != tc.want.requests {
+				t.Errorf("\n%s\nUpdate(...): made %d requests, want requests: %v\n", tc.reason, requests, tc.want.requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package user
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/statnett/provider-cloudian/apis/user/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+const (
+	errGetPasswordSecret   = "cannot get password Secret of User"
+	errNoPassword          = "password Secret of User has no key %q"
+	errGeneratePassword    = "cannot generate password of User"
+	errSetPassword         = "cannot set password of User"
+	errGetConnectionSecret = "cannot get connection secret of User"
+
+	// keyPassword is the connection secret key of a generated password.
+	keyPassword = "password"
+	// passwordGenerated is the password version of a generated password.
+	passwordGenerated = "Generated"
+
+	generatedPasswordLength = 24
+)
+
+// Characters of generated passwords, which include each class to satisfy the
+// password policy of the CMC.
+var passwordClasses = []string{
+	"ABCDEFGHJKLMNPQRSTUVWXYZ",
+	"abcdefghijkmnopqrstuvwxyz",
+	"23456789",
+	"!#%+-.=@_",
+}
+
+// desiredPassword returns the password of a User from its Secret, and its
+// version, or empty strings if it is not read from a Secret.
+func (c *external) desiredPassword(ctx context.Context, cr *v1alpha1.User) (password, version string, err error) {
+	ref := cr.Spec.ForProvider.PasswordSecretRef
+	if ref == nil {
+		return "", "", nil
+	}
+	secret := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		return "", "", errors.Wrap(err, errGetPasswordSecret)
+	}
+	data, ok := secret.Data[ref.Key]
+	if !ok || len(data) == 0 {
+		return "", "", errors.Errorf(errNoPassword, ref.Key)
+	}
+	return string(data), passwordVersion(cr, string(data)), nil
+}
+
+// passwordVersion returns a hash of a password salted with the UID of its
+// User, which changes with the password but not with the rest of its Secret.
+func passwordVersion(cr *v1alpha1.User, password string) string {
+	mac := hmac.New(sha256.New, []byte(cr.GetUID()))
+	mac.Write([]byte(password))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// passwordUpToDate reports whether the password of a User was set, and, if it
+// is read from a Secret, whether the password in the Secret has not changed
+// since.
+func (c *external) passwordUpToDate(ctx context.Context, cr *v1alpha1.User) (bool, error) {
+	switch {
+	case cr.Spec.ForProvider.PasswordSecretRef != nil:
+		_, version, err := c.desiredPassword(ctx, cr)
+		if err != nil {
+			return false, err
+		}
+		return hmac.Equal([]byte(cr.Status.AtProvider.PasswordVersion), []byte(version)), nil
+	case cr.Spec.ForProvider.GeneratePassword:
+		if cr.Status.AtProvider.PasswordVersion == passwordGenerated {
+			return true, nil
+		}
+		// The connection secret is published before the status of the User
+		// is persisted, which may fail.
+		published, err := c.passwordPublished(ctx, cr)
+		if published {
+			cr.Status.AtProvider.PasswordVersion = passwordGenerated
+		}
+		return published, err
+	default:
+		return true, nil
+	}
+}
+
+// passwordPublished reports whether the connection secret of a User has a
+// generated password.
+func (c *external) passwordPublished(ctx context.Context, cr *v1alpha1.User) (bool, error) {
+	ref := cr.GetWriteConnectionSecretToReference()
+	if ref == nil {
+		return false, nil
+	}
+	secret := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		return false, errors.Wrap(resource.IgnoreNotFound(err), errGetConnectionSecret)
+	}
+	return len(secret.Data[keyPassword]) > 0, nil
+}
+
+// setPassword sets the password of a User from its Secret, or a generated
+// one, and records its version in the status of the User. A generated
+// password is returned in the connection details to publish.
+func (c *external) setPassword(ctx context.Context, cr *v1alpha1.User) (managed.ConnectionDetails, error) {
+	details := managed.ConnectionDetails{}
+	password, version, err := c.desiredPassword(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+	if password == "" {
+		if !cr.Spec.ForProvider.GeneratePassword {
+			return details, nil
+		}
+		if password, err = generatePassword(); err != nil {
+			return nil, errors.Wrap(err, errGeneratePassword)
+		}
+		version = passwordGenerated
+		details[keyPassword] = []byte(password)
+	}
+
+	guid := cloudian.GroupUserID{GroupID: cr.Spec.ForProvider.GroupID, UserID: meta.GetExternalName(cr)}
+	if err := c.cloudianService.SetUserPassword(ctx, guid, password); err != nil {
+		return nil, errors.Wrap(err, errSetPassword)
+	}
+	cr.Status.AtProvider.PasswordVersion = version
+	return details, nil
+}
+
+func generatePassword() (string, error) {
+	all := strings.Join(passwordClasses, "")
+	for {
+		password := make([]byte, generatedPasswordLength)
+		for i := range password {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(all))))
+			if err != nil {
+				return "", err
+			}
+			password[i] = all[n.Int64()]
+		}
+		if hasAllClasses(string(password)) {
+			return string(password), nil
+		}
+	}
+}
+
+func hasAllClasses(password string) bool {
+	for _, class := range passwordClasses {
+		if !strings.ContainsAny(password, class) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package user
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/statnett/provider-cloudian/apis/user/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+func TestPasswordUpToDate(t *testing.T) {
+	ref := &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "bar", Namespace: "default"}, Key: "password"}
+	connRef := &xpv1.SecretReference{Name: "conn", Namespace: "default"}
+	user := &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{UID: "uid"}}
+	kube := &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		s := obj.(*corev1.Secret)
+		switch key.Name {
+		case "bar":
+			s.Data = map[string][]byte{"password": []byte("s3cret"), "username": []byte("bar")}
+		case "conn":
+			s.Data = map[string][]byte{keyPassword: []byte("generated")}
+		default:
+			return kerrors.NewNotFound(corev1.Resource("secrets"), key.Name)
+		}
+		return nil
+	}}
+
+	type want struct {
+		upToDate bool
+		version  string
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		params  v1alpha1.UserParameters
+		connRef *xpv1.SecretReference
+		version string
+		want    want
+	}{
+		"NoPassword": {
+			reason: "A User without a password should be up to date.",
+			want:   want{upToDate: true},
+		},
+		"SecretApplied": {
+			reason:  "A User whose Secret password was set should be up to date.",
+			params:  v1alpha1.UserParameters{PasswordSecretRef: ref},
+			version: passwordVersion(user, "s3cret"),
+			want:    want{upToDate: true, version: passwordVersion(user, "s3cret")},
+		},
+		"SecretChanged": {
+			reason:  "A User whose Secret password changed should not be up to date.",
+			params:  v1alpha1.UserParameters{PasswordSecretRef: ref},
+			version: passwordVersion(user, "old"),
+			want:    want{version: passwordVersion(user, "old")},
+		},
+		"OtherUser": {
+			reason:  "A User whose version is the hash of the same password for another User should not be up to date.",
+			params:  v1alpha1.UserParameters{PasswordSecretRef: ref},
+			version: passwordVersion(&v1alpha1.User{ObjectMeta: metav1.ObjectMeta{UID: "other"}}, "s3cret"),
+			want:    want{version: passwordVersion(&v1alpha1.User{ObjectMeta: metav1.ObjectMeta{UID: "other"}}, "s3cret")},
+		},
+		"SecretMissingKey": {
+			reason: "A Secret without the password key should return an error.",
+			params: v1alpha1.UserParameters{PasswordSecretRef: &xpv1.SecretKeySelector{SecretReference: ref.SecretReference, Key: "other"}},
+			want:   want{err: errors.Errorf(errNoPassword, "other")},
+		},
+		"GeneratedUnset": {
+			reason:  "A User whose password has not been generated should not be up to date.",
+			params:  v1alpha1.UserParameters{GeneratePassword: true},
+			connRef: &xpv1.SecretReference{Name: "missing", Namespace: "default"},
+		},
+		"Generated": {
+			reason:  "A User whose password was generated should be up to date.",
+			params:  v1alpha1.UserParameters{GeneratePassword: true},
+			version: passwordGenerated,
+			want:    want{upToDate: true, version: passwordGenerated},
+		},
+		"GeneratedPublished": {
+			reason:  "A User whose generated password was published, but not recorded in its status, should be up to date.",
+			params:  v1alpha1.UserParameters{GeneratePassword: true},
+			connRef: connRef,
+			want:    want{upToDate: true, version: passwordGenerated},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.User{ObjectMeta: user.ObjectMeta, Spec: v1alpha1.UserSpec{ForProvider: tc.params}}
+			cr.SetWriteConnectionSecretToReference(tc.connRef)
+			cr.Status.AtProvider.PasswordVersion = tc.version
+			e := external{kube: kube}
+
+			got, err := e.passwordUpToDate(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.passwordUpToDate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if got != tc.want.upToDate {
+				t.Errorf("\n%s\ne.passwordUpToDate(...): want %t, got %t\n", tc.reason, tc.want.upToDate, got)
+			}
+			if diff := cmp.Diff(tc.want.version, cr.Status.AtProvider.PasswordVersion); diff != "" {
+				t.Errorf("\n%s\ne.passwordUpToDate(...): -want version, +got version:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetGeneratedPassword(t *testing.T) {
+	var password string
+	admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/user/password" {
+			t.Errorf("request = %s %s, want POST /user/password", r.Method, r.URL.Path)
+		}
+		password = r.PostFormValue("password")
+	}))
+	defer admin.Close()
+
+	cr := &v1alpha1.User{Spec: v1alpha1.UserSpec{ForProvider: v1alpha1.UserParameters{GroupID: "foo", GeneratePassword: true}}}
+	meta.SetExternalName(cr, "bar")
+	e := external{cloudianService: cloudian.NewClient(admin.URL, "")}
+
+	details, err := e.setPassword(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.setPassword(...): %v", err)
+	}
+	if len(password) != generatedPasswordLength || !hasAllClasses(password) {
+		t.Errorf("e.setPassword(...): password %q is not a generated password", password)
+	}
+	if got := string(details[keyPassword]); got != password {
+		t.Errorf("e.setPassword(...): published password %q, want %q", got, password)
+	}
+	if cr.Status.AtProvider.PasswordVersion != passwordGenerated {
+		t.Errorf("e.setPassword(...): generated password was not recorded")
+	}
+}
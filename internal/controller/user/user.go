@@ -18,10 +18,10 @@ package user
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/pkg/errors"
-	"k8s.io/apimachinery/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -36,31 +36,35 @@ import (
 
 	"github.com/statnett/provider-cloudian/apis/user/v1alpha1"
 	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/controller/connectiontemplate"
+	"github.com/statnett/provider-cloudian/internal/controller/connector"
+	"github.com/statnett/provider-cloudian/internal/controller/lateinit"
+	"github.com/statnett/provider-cloudian/internal/controller/usage"
 	"github.com/statnett/provider-cloudian/internal/features"
+	"github.com/statnett/provider-cloudian/internal/metrics"
 	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
 )
 
 const (
-	errNotUser      = "managed resource is not a User custom resource"
-	errTrackPCUsage = "cannot track ProviderConfig usage"
-	errGetPC        = "cannot get ProviderConfig"
-	errGetCreds     = "cannot get credentials"
+	errNotUser = "managed resource is not a User custom resource"
 
-	errNewClient  = "cannot create new Service"
 	errCreateUser = "cannot create User"
 	errDeleteUser = "cannot delete User"
 	errGetUser    = "cannot get User"
-)
-
-var (
-	newCloudianService = func(providerConfig *apisv1alpha1.ProviderConfig, authHeader string) (*cloudian.Client, error) {
-		// FIXME: Don't require InsecureSkipVerify
-		return cloudian.NewClient(
-			providerConfig.Spec.Endpoint,
-			authHeader,
-			cloudian.WithInsecureTLSVerify(true),
-		), nil
-	}
+	errUpdateUser = "cannot update User"
+	errUserExists = "User already exists in Cloudian, and is observed on the next reconcile"
+	errNoGroupID  = "groupId is not set, and could not be resolved from groupIdRef or groupIdSelector"
+
+	errUserTypeMismatch = "userType %q does not match the type %q of the user in Cloudian, which cannot be changed"
+
+	errHasAccessKeys     = "User has access keys and cannot be deleted"
+	errPurgeNotConfirmed = "purgeOnDelete is set, but the %s annotation is not set to the user ID %q to confirm purging the data of the User"
+	errPurgeUser         = "cannot purge User"
+	errGetPurgeProgress  = "cannot get purge progress of User"
+	errDeleteAccessKeys  = "cannot delete access keys of User"
+	errListInitialKey    = "cannot list initial access key of User"
+	errDeleteInitialKey  = "cannot delete initial access key of User"
+	errGetInitialKey     = "cannot get initial access key of User"
 )
 
 // Setup adds a controller that reconciles User managed resources.
@@ -72,65 +76,40 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
-	r := managed.NewReconciler(mgr,
-		resource.ManagedKind(v1alpha1.UserGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
-			kube:         mgr.GetClient(),
-			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newServiceFn: newCloudianService}),
+	var tenants *metrics.Tenants
+	if o.Features.Enabled(features.EnableAlphaTenantMetrics) {
+		tenants = metrics.DefaultTenants
+	}
+
+	conn := connector.New(mgr.GetClient(), newExternalFn(mgr.GetClient(), tenants))
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(conn),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		managed.WithConnectionPublishers(cps...))
+		managed.WithConnectionPublishers(cps...),
+	}
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.UserGroupVersionKind), opts...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1alpha1.User{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, conn.Reconciler(r, o.PollInterval), o.GlobalRateLimiter))
 }
 
-// A connector is expected to produce an ExternalClient when its Connect method
-// is called.
-type connector struct {
-	kube         client.Client
-	usage        resource.Tracker
-	newServiceFn func(providerConfig *apisv1alpha1.ProviderConfig, authHeader string) (*cloudian.Client, error)
-}
-
-// Connect typically produces an ExternalClient by:
-// 1. Tracking that the managed resource is using a ProviderConfig.
-// 2. Getting the managed resource's ProviderConfig.
-// 3. Getting the credentials specified by the ProviderConfig.
-// 4. Using the credentials to form a client.
-func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mg.(*v1alpha1.User)
-	if !ok {
-		return nil, errors.New(errNotUser)
+// newExternalFn returns a function that produces an ExternalClient from a
+// Cloudian client, which reads password Secrets with kube and records observed
+// usage in tenants.
+func newExternalFn(kube client.Client, tenants *metrics.Tenants) connector.NewExternalFn {
+	return func(svc *cloudian.Client, pc *apisv1alpha1.ProviderConfig) managed.ExternalClient {
+		return &external{cloudianService: svc, kube: kube, endpoint: pc.Spec.GetEndpoints()[0], usage: pc.Spec.Usage, s3: pc.Spec.S3, tenants: tenants}
 	}
-
-	if err := c.usage.Track(ctx, mg); err != nil {
-		return nil, errors.Wrap(err, errTrackPCUsage)
-	}
-
-	pc := &apisv1alpha1.ProviderConfig{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
-		return nil, errors.Wrap(err, errGetPC)
-	}
-
-	cd := pc.Spec.AuthHeader
-	authHeader, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
-	if err != nil {
-		return nil, errors.Wrap(err, errGetCreds)
-	}
-
-	svc, err := c.newServiceFn(pc, string(authHeader))
-	if err != nil {
-		return nil, errors.Wrap(err, errNewClient)
-	}
-
-	return &external{cloudianService: svc}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
@@ -139,6 +118,11 @@ type external struct {
 	// A 'client' used to connect to the external resource API. In practice this
 	// would be something like an AWS SDK client.
 	cloudianService *cloudian.Client
+	kube            client.Client
+	endpoint        string
+	usage           *apisv1alpha1.UsageConfig
+	s3              *apisv1alpha1.S3Config
+	tenants         *metrics.Tenants
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -154,7 +138,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	group := cr.Spec.ForProvider.GroupID
 	if group == "" {
-		return managed.ExternalObservation{}, nil
+		return managed.ExternalObservation{}, errors.New(errNoGroupID)
 	}
 
 	user, err := c.cloudianService.GetUser(ctx, cloudian.GroupUserID{
@@ -166,8 +150,32 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errGetUser)
 	}
+	if t := cr.Spec.ForProvider.UserType; t != "" && cloudian.UserType(t) != user.UserType {
+		return managed.ExternalObservation{}, errors.Errorf(errUserTypeMismatch, t, user.UserType)
+	}
 
-	cr.Status.AtProvider.CanonicalID = user.CanonicalID
+	lastUsage := cr.Status.AtProvider.Usage
+	last := cr.Status.AtProvider
+	cr.Status.AtProvider = newObservation(*user)
+	cr.Status.AtProvider.Purge = last.Purge
+	cr.Status.AtProvider.InitialAccessKey = cr.GetAnnotations()[v1alpha1.AnnotationInitialAccessKey]
+	cr.Status.AtProvider.PasswordVersion = last.PasswordVersion
+	guid := cloudian.GroupUserID{GroupID: group, UserID: externalName}
+	cr.Status.AtProvider.Usage = usage.Update(ctx, c.cloudianService, c.usage, cr, guid, lastUsage)
+	usage.Record(c.tenants, guid, cr.Status.AtProvider.Usage)
+	details, err := c.initialAccessKeyDetails(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	c.addTemplateDetails(cr, guid, details)
+	// The password Secret of a User may be deleted before it.
+	passwordUpToDate := true
+	if !meta.WasDeleted(cr) {
+		if passwordUpToDate, err = c.passwordUpToDate(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+	lateInitialized := lateInitialize(&cr.Spec.ForProvider, *user)
 	cr.SetConditions(xpv1.Available())
 
 	return managed.ExternalObservation{
@@ -176,14 +184,18 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		// (re)create the resource, or that it has successfully been deleted.
 		ResourceExists: true,
 
+		// Return true when unset spec fields were filled from the external
+		// resource, so that the managed resource reconciler persists them.
+		ResourceLateInitialized: lateInitialized,
+
 		// Return false when the external resource exists, but it not up to date
 		// with the desired managed resource state. This lets the managed
 		// resource reconciler know that it needs to call Update.
-		ResourceUpToDate: true,
+		ResourceUpToDate: isUpToDate(externalName, cr.Spec.ForProvider, *user) && passwordUpToDate,
 
 		// Return any details that may be required to connect to the external
 		// resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: details,
 	}, nil
 }
 
@@ -193,33 +205,26 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotUser)
 	}
 
-	user := cloudian.User{
-		GroupUserID: cloudian.GroupUserID{
-			GroupID: cr.Spec.ForProvider.GroupID,
-			UserID:  meta.GetExternalName(mg),
-		},
-		UserType: cloudian.UserTypeStandard,
+	user := newCloudianUser(meta.GetExternalName(mg), cr.Spec.ForProvider)
+	err := c.cloudianService.CreateUser(ctx, user)
+	if cloudian.IsConflict(err) {
+		return managed.ExternalCreation{}, errors.Wrap(err, errUserExists)
 	}
-	if err := c.cloudianService.CreateUser(ctx, user); err != nil {
+	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreateUser)
 	}
 
-	// When Cloudian creates a user, a single access key is created inside it.
-	// Delete the access key, so that the user does not have any non-managed access keys.
-	creds, err := c.cloudianService.ListUserCredentials(ctx, user.GroupUserID)
+	// The password is set by the first Update, as the status of the User is
+	// not persisted after it is created.
+	details, err := c.applyInitialAccessKeyPolicy(ctx, cr, user.GroupUserID)
 	if err != nil {
-		return managed.ExternalCreation{}, errors.Wrap(err, "failed to list access keys of user")
-	}
-	for _, cred := range creds {
-		if err := c.cloudianService.DeleteUserCredentials(ctx, cred.AccessKey); err != nil {
-			return managed.ExternalCreation{}, errors.Wrap(err, "failed to delete initial access key of user")
-		}
+		return managed.ExternalCreation{}, err
 	}
 
 	return managed.ExternalCreation{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: details,
 	}, nil
 }
 
@@ -229,12 +234,25 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotUser)
 	}
 
-	fmt.Printf("Pretending to Update (no managed fields to update): %+v", cr)
+	if err := c.cloudianService.UpdateUser(ctx, newCloudianUser(meta.GetExternalName(mg), cr.Spec.ForProvider)); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateUser)
+	}
+
+	details := managed.ConnectionDetails{}
+	upToDate, err := c.passwordUpToDate(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if !upToDate {
+		if details, err = c.setPassword(ctx, cr); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
 
 	return managed.ExternalUpdate{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: details,
 	}, nil
 }
 
@@ -249,21 +267,260 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 		UserID:  meta.GetExternalName(mg),
 	}
 
+	if cr.Spec.ForProvider.PurgeOnDelete {
+		if cr.GetAnnotations()[v1alpha1.AnnotationConfirmPurge] != guid.UserID {
+			return managed.ExternalDelete{}, errors.Errorf(errPurgeNotConfirmed, v1alpha1.AnnotationConfirmPurge, guid.UserID)
+		}
+		purged, err := c.purge(ctx, cr, guid)
+		if err != nil || !purged {
+			// Delete is called again, until the data of the user is purged.
+			return managed.ExternalDelete{}, err
+		}
+	}
+
 	creds, err := c.cloudianService.ListUserCredentials(ctx, guid)
 	if err != nil {
 		return managed.ExternalDelete{}, err
 	}
-	if len(creds) > 0 {
-		return managed.ExternalDelete{}, errors.New("User has access keys and cannot be deleted")
+	for _, cred := range creds {
+		// The initial access key is deleted with the user.
+		if !cr.Spec.ForProvider.PurgeOnDelete && cred.AccessKey != cr.GetAnnotations()[v1alpha1.AnnotationInitialAccessKey] {
+			return managed.ExternalDelete{}, errors.New(errHasAccessKeys)
+		}
+	}
+	for _, cred := range creds {
+		if err := c.cloudianService.DeleteUserCredentials(ctx, cred.AccessKey); err != nil && !errors.Is(err, cloudian.ErrNotFound) {
+			return managed.ExternalDelete{}, errors.Wrap(err, errDeleteAccessKeys)
+		}
 	}
 
-	if err := c.cloudianService.DeleteUser(ctx, guid); err != nil {
+	if err := c.cloudianService.DeleteUser(ctx, guid); err != nil && !errors.Is(err, cloudian.ErrNotFound) {
 		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteUser)
 	}
+	c.tenants.DeleteUsage(guid)
 
 	return managed.ExternalDelete{}, nil
 }
 
+// applyInitialAccessKeyPolicy deletes or keeps the access key Cloudian
+// created with a user, records which key was kept in an annotation of the
+// User, and returns the connection details to publish.
+func (c *external) applyInitialAccessKeyPolicy(ctx context.Context, cr *v1alpha1.User, guid cloudian.GroupUserID) (managed.ConnectionDetails, error) {
+	policy := cr.Spec.ForProvider.InitialAccessKeyPolicy
+	if policy == "" {
+		policy = v1alpha1.InitialAccessKeyDelete
+	}
+
+	// When Cloudian creates a user, a single access key is created inside it.
+	creds, err := c.cloudianService.ListUserCredentials(ctx, guid)
+	if err != nil {
+		return nil, errors.Wrap(err, errListInitialKey)
+	}
+
+	if policy == v1alpha1.InitialAccessKeyDelete {
+		// Delete the access key, so that the user does not have any
+		// non-managed access keys.
+		for _, cred := range creds {
+			if err := c.cloudianService.DeleteUserCredentials(ctx, cred.AccessKey); err != nil {
+				return nil, errors.Wrap(err, errDeleteInitialKey)
+			}
+		}
+		return managed.ConnectionDetails{}, nil
+	}
+
+	if len(creds) == 0 {
+		return managed.ConnectionDetails{}, nil
+	}
+	// The managed reconciler persists the annotations of the User after it is
+	// created, but reverts its status.
+	meta.AddAnnotations(cr, map[string]string{v1alpha1.AnnotationInitialAccessKey: creds[0].AccessKey})
+	if policy != v1alpha1.InitialAccessKeyPublish {
+		return managed.ConnectionDetails{}, nil
+	}
+	return c.connectionDetails(&creds[0]), nil
+}
+
+// initialAccessKeyDetails returns the connection details of the initial
+// access key of a User, if it is published.
+func (c *external) initialAccessKeyDetails(ctx context.Context, cr *v1alpha1.User) (managed.ConnectionDetails, error) {
+	key := cr.Status.AtProvider.InitialAccessKey
+	if key == "" || cr.Spec.ForProvider.InitialAccessKeyPolicy != v1alpha1.InitialAccessKeyPublish {
+		return managed.ConnectionDetails{}, nil
+	}
+
+	creds, err := c.cloudianService.GetUserCredentials(ctx, key)
+	if errors.Is(err, cloudian.ErrNotFound) {
+		// The initial access key was deleted outside of the provider.
+		cr.Status.AtProvider.InitialAccessKey = ""
+		return managed.ConnectionDetails{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errGetInitialKey)
+	}
+	return c.connectionDetails(creds), nil
+}
+
+// connectionDetails publishes an access key of a User, along with the S3
+// endpoint of its ProviderConfig.
+func (c *external) connectionDetails(creds *cloudian.SecurityInfo) managed.ConnectionDetails {
+	details := managed.ConnectionDetails{
+		"accessKey": []byte(creds.AccessKey),
+		"secretKey": []byte(creds.SecretKey),
+	}
+	if c.s3 != nil {
+		details["endpoint"] = []byte(c.s3.Endpoint)
+		if c.s3.Region != "" {
+			details["region"] = []byte(c.s3.Region)
+		}
+	}
+	return details
+}
+
+// addTemplateDetails renders the connection templates of a User into details,
+// which hold the initial access key if it is published. Templates that cannot
+// be rendered are reported by a condition, while the remaining connection
+// details are still published.
+func (c *external) addTemplateDetails(cr *v1alpha1.User, guid cloudian.GroupUserID, details managed.ConnectionDetails) {
+	if len(cr.Spec.ForProvider.ConnectionTemplates) == 0 {
+		return
+	}
+
+	data := connectiontemplate.Data{
+		AccessKey:   string(details["accessKey"]),
+		SecretKey:   string(details["secretKey"]),
+		UserID:      guid.UserID,
+		GroupID:     guid.GroupID,
+		CanonicalID: cr.Status.AtProvider.CanonicalID,
+		Endpoint:    c.endpoint,
+	}
+	if c.s3 != nil {
+		data.S3Endpoint = c.s3.Endpoint
+		data.Region = c.s3.Region
+	}
+
+	rendered, err := connectiontemplate.Render(cr.Spec.ForProvider.ConnectionTemplates, data)
+	if err != nil {
+		cr.SetConditions(v1alpha1.ConnectionTemplateError(err))
+		return
+	}
+	cr.SetConditions(v1alpha1.ConnectionTemplatesRendered())
+	for key, value := range rendered {
+		details[key] = value
+	}
+}
+
+// purge starts purging the data of a user, and reports its progress in the
+// status of the User. It returns true once the user has no data left.
+func (c *external) purge(ctx context.Context, cr *v1alpha1.User, guid cloudian.GroupUserID) (bool, error) {
+	if cr.Status.AtProvider.Purge == nil {
+		err := c.cloudianService.PurgeUser(ctx, guid)
+		if errors.Is(err, cloudian.ErrNotFound) {
+			return true, nil
+		}
+		if err != nil {
+			return false, errors.Wrap(err, errPurgeUser)
+		}
+		cr.Status.AtProvider.Purge = &v1alpha1.PurgeObservation{StartTime: metav1.Now()}
+	}
+
+	stored, err := c.cloudianService.GetStoredUsage(ctx, guid)
+	if errors.Is(err, cloudian.ErrNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, errGetPurgeProgress)
+	}
+
+	p := cr.Status.AtProvider.Purge
+	p.RemainingBytes = stored.StorageBytes
+	p.RemainingObjects = stored.ObjectCount
+	p.LastUpdated = metav1.Now()
+	return stored.StorageBytes == 0 && stored.ObjectCount == 0, nil
+}
+
 func (c *external) Disconnect(ctx context.Context) error {
 	return nil
 }
+
+// isUpToDate compares the managed profile attributes of a user. Fields set by
+// Cloudian, such as the canonical ID, are ignored, and so is the user type,
+// which cannot be changed after creation.
+func isUpToDate(name string, desired v1alpha1.UserParameters, observed cloudian.User) bool {
+	expected := newCloudianUser(name, desired)
+	expected.CanonicalID = observed.CanonicalID
+	// A mismatching user type fails Observe, as it cannot be changed.
+	expected.UserType = observed.UserType
+	return expected == observed
+}
+
+func newCloudianUser(name string, up v1alpha1.UserParameters) cloudian.User {
+	return cloudian.User{
+		GroupUserID: cloudian.GroupUserID{
+			GroupID: up.GroupID,
+			UserID:  name,
+		},
+		UserType:     userType(up.UserType),
+		Active:       ptr.Deref(up.Active, true),
+		FullName:     ptr.Deref(up.FullName, ""),
+		EmailAddr:    ptr.Deref(up.EmailAddr, ""),
+		Address1:     ptr.Deref(up.Address1, ""),
+		Address2:     ptr.Deref(up.Address2, ""),
+		City:         ptr.Deref(up.City, ""),
+		State:        ptr.Deref(up.State, ""),
+		Zip:          ptr.Deref(up.Zip, ""),
+		Country:      ptr.Deref(up.Country, ""),
+		Phone:        ptr.Deref(up.Phone, ""),
+		LDAPEnabled:  ptr.Deref(up.LDAPEnabled, false),
+		RatingPlanID: ptr.Deref(up.RatingPlanID, ""),
+	}
+}
+
+func newObservation(u cloudian.User) v1alpha1.UserObservation {
+	return v1alpha1.UserObservation{
+		CanonicalID:  u.CanonicalID,
+		UserType:     string(u.UserType),
+		Active:       u.Active,
+		FullName:     u.FullName,
+		EmailAddr:    u.EmailAddr,
+		Address1:     u.Address1,
+		Address2:     u.Address2,
+		City:         u.City,
+		State:        u.State,
+		Zip:          u.Zip,
+		Country:      u.Country,
+		Phone:        u.Phone,
+		LDAPEnabled:  u.LDAPEnabled,
+		RatingPlanID: u.RatingPlanID,
+	}
+}
+
+// lateInitialize fills unset parameters from the observed user, and reports
+// whether any were set.
+func lateInitialize(up *v1alpha1.UserParameters, observed cloudian.User) bool {
+	li := false
+	// Unlike the other fields, an inactive user is not the zero value.
+	if up.Active == nil {
+		up.Active = ptr.To(observed.Active)
+		li = true
+	}
+	li = lateinit.Value(&up.UserType, string(observed.UserType)) || li
+	li = lateinit.Ptr(&up.FullName, observed.FullName) || li
+	li = lateinit.Ptr(&up.EmailAddr, observed.EmailAddr) || li
+	li = lateinit.Ptr(&up.Address1, observed.Address1) || li
+	li = lateinit.Ptr(&up.Address2, observed.Address2) || li
+	li = lateinit.Ptr(&up.City, observed.City) || li
+	li = lateinit.Ptr(&up.State, observed.State) || li
+	li = lateinit.Ptr(&up.Zip, observed.Zip) || li
+	li = lateinit.Ptr(&up.Country, observed.Country) || li
+	li = lateinit.Ptr(&up.Phone, observed.Phone) || li
+	li = lateinit.Ptr(&up.LDAPEnabled, observed.LDAPEnabled) || li
+	li = lateinit.Ptr(&up.RatingPlanID, observed.RatingPlanID) || li
+	return li
+}
+
+func userType(t string) cloudian.UserType {
+	if t == "" {
+		return cloudian.UserTypeStandard
+	}
+	return cloudian.UserType(t)
+}
@@ -18,13 +18,33 @@ package user
 
 import (
 	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/statnett/provider-cloudian/apis/user/v1alpha1"
+	apisv1alpha1 "github.com/statnett/provider-cloudian/apis/v1alpha1"
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -36,37 +56,620 @@ import (
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
 func TestObserve(t *testing.T) {
-	type fields struct {
+	type want struct {
+		o      managed.ExternalObservation
+		active *bool
+		err    error
 	}
 
-	type args struct {
-		ctx context.Context
-		mg  resource.Managed
+	cases := map[string]struct {
+		reason   string
+		params   v1alpha1.UserParameters
+		deleted  bool
+		observed map[string]any
+		want     want
+	}{
+		"NotFound": {
+			reason: "A user that does not exist should not be reported as existing.",
+			params: v1alpha1.UserParameters{GroupID: "foo"},
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"LateInitializeInactive": {
+			reason:   "A user disabled in the CMC should be late-initialized as inactive, not reactivated.",
+			params:   v1alpha1.UserParameters{GroupID: "foo"},
+			observed: map[string]any{"active": "false"},
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceLateInitialized: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				active: ptr.To(false),
+			},
+		},
+		"Inactive": {
+			reason:   "A user that should be active, but is disabled in the CMC, should not be up to date.",
+			params:   v1alpha1.UserParameters{GroupID: "foo", UserType: "User", Active: ptr.To(true)},
+			observed: map[string]any{"active": "false"},
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ConnectionDetails: managed.ConnectionDetails{}},
+				active: ptr.To(true),
+			},
+		},
+		"ProfileDrift": {
+			reason:   "A user with a profile attribute changed in the CMC should not be up to date.",
+			params:   v1alpha1.UserParameters{GroupID: "foo", UserType: "User", Active: ptr.To(true), FullName: ptr.To("Baz")},
+			observed: map[string]any{"active": "true", "fullName": "Bar"},
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ConnectionDetails: managed.ConnectionDetails{}},
+				active: ptr.To(true),
+			},
+		},
+		"UpToDate": {
+			reason:   "A user matching its profile attributes should be up to date.",
+			params:   v1alpha1.UserParameters{GroupID: "foo", UserType: "User", Active: ptr.To(true), FullName: ptr.To("Bar")},
+			observed: map[string]any{"active": "true", "fullName": "Bar"},
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				active: ptr.To(true),
+			},
+		},
+		"UserTypeMismatch": {
+			reason:   "A user whose type differs from the type in Cloudian should return an error, as the type cannot be changed.",
+			params:   v1alpha1.UserParameters{GroupID: "foo", UserType: "User", Active: ptr.To(true)},
+			observed: map[string]any{"active": "true", "userType": "GroupAdmin"},
+			want: want{
+				active: ptr.To(true),
+				err:    errors.Errorf(errUserTypeMismatch, "User", cloudian.UserTypeGroupAdmin),
+			},
+		},
+		"Deleted": {
+			reason:   "The password Secret of a User being deleted should not be read, as it may already be deleted.",
+			params:   v1alpha1.UserParameters{GroupID: "foo", UserType: "User", Active: ptr.To(true), PasswordSecretRef: &xpv1.SecretKeySelector{Key: "password"}},
+			deleted:  true,
+			observed: map[string]any{"active": "true"},
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				active: ptr.To(true),
+			},
+		},
 	}
 
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tc.observed == nil {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				user := map[string]any{"groupId": "foo", "userId": "bar", "userType": "User"}
+				maps.Copy(user, tc.observed)
+				json.NewEncoder(w).Encode(user)
+			}))
+			defer admin.Close()
+
+			cr := &v1alpha1.User{Spec: v1alpha1.UserSpec{ForProvider: tc.params}}
+			meta.SetExternalName(cr, "bar")
+			if tc.deleted {
+				cr.SetDeletionTimestamp(ptr.To(metav1.Now()))
+			}
+			e := external{
+				cloudianService: cloudian.NewClient(admin.URL, ""),
+				kube:            &test.MockClient{MockGet: test.NewMockGetFn(errors.New("boom"))},
+				usage:           &apisv1alpha1.UsageConfig{PollInterval: &metav1.Duration{}},
+			}
+
+			got, err := e.Observe(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.active, cr.Spec.ForProvider.Active); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want active, +got active:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitialize(t *testing.T) {
+	observed := cloudian.User{
+		GroupUserID:  cloudian.GroupUserID{GroupID: "foo", UserID: "bar"},
+		UserType:     cloudian.UserTypeGroupAdmin,
+		Active:       true,
+		FullName:     "Bar",
+		EmailAddr:    "bar@example.com",
+		RatingPlanID: "Default-RP",
+	}
+
+	cases := map[string]struct {
+		reason string
+		params v1alpha1.UserParameters
+		want   v1alpha1.UserParameters
+		li     bool
+	}{
+		"Unset": {
+			reason: "Unset parameters should be filled from the observed user.",
+			params: v1alpha1.UserParameters{GroupID: "foo"},
+			want: v1alpha1.UserParameters{
+				GroupID:      "foo",
+				UserType:     "GroupAdmin",
+				Active:       ptr.To(true),
+				FullName:     ptr.To("Bar"),
+				EmailAddr:    ptr.To("bar@example.com"),
+				RatingPlanID: ptr.To("Default-RP"),
+			},
+			li: true,
+		},
+		"Set": {
+			reason: "Set parameters should not be overwritten.",
+			params: v1alpha1.UserParameters{GroupID: "foo", UserType: "GroupAdmin", Active: ptr.To(true), FullName: ptr.To("Baz"), EmailAddr: ptr.To("baz@example.com"), RatingPlanID: ptr.To("Gold")},
+			want:   v1alpha1.UserParameters{GroupID: "foo", UserType: "GroupAdmin", Active: ptr.To(true), FullName: ptr.To("Baz"), EmailAddr: ptr.To("baz@example.com"), RatingPlanID: ptr.To("Gold")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			li := lateInitialize(&tc.params, observed)
+			if li != tc.li {
+				t.Errorf("\n%s\nlateInitialize(...): want %t, got %t\n", tc.reason, tc.li, li)
+			}
+			if diff := cmp.Diff(tc.want, tc.params); diff != "" {
+				t.Errorf("\n%s\nlateInitialize(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNewCloudianUser(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		params v1alpha1.UserParameters
+		want   cloudian.UserType
+	}{
+		"DefaultUserType": {
+			reason: "A user without a user type should be a standard user.",
+			params: v1alpha1.UserParameters{GroupID: "foo"},
+			want:   cloudian.UserTypeStandard,
+		},
+		"GroupAdmin": {
+			reason: "A group admin should be created as a group admin.",
+			params: v1alpha1.UserParameters{GroupID: "foo", UserType: "GroupAdmin"},
+			want:   cloudian.UserTypeGroupAdmin,
+		},
+		"SystemAdmin": {
+			reason: "A system admin should be created as a system admin.",
+			params: v1alpha1.UserParameters{GroupID: "0", UserType: "SystemAdmin"},
+			want:   cloudian.UserTypeSystemAdmin,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := newCloudianUser("bar", tc.params).UserType
+			if got != tc.want {
+				t.Errorf("\n%s\nnewCloudianUser(...): want user type %q, got %q\n", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	observed := cloudian.User{
+		GroupUserID: cloudian.GroupUserID{GroupID: "foo", UserID: "bar"},
+		UserType:    cloudian.UserTypeGroupAdmin,
+		CanonicalID: "123",
+		Active:      true,
+		FullName:    "Bar",
+	}
+
+	cases := map[string]struct {
+		reason string
+		params v1alpha1.UserParameters
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "A user matching its profile attributes should be up to date.",
+			params: v1alpha1.UserParameters{GroupID: "foo", UserType: "GroupAdmin", Active: ptr.To(true), FullName: ptr.To("Bar")},
+			want:   true,
+		},
+		"UserTypeIgnored": {
+			reason: "The user type cannot be changed after creation, so it should not be compared.",
+			params: v1alpha1.UserParameters{GroupID: "foo", UserType: "User", Active: ptr.To(true), FullName: ptr.To("Bar")},
+			want:   true,
+		},
+		"ProfileChanged": {
+			reason: "A user with a changed profile attribute should not be up to date.",
+			params: v1alpha1.UserParameters{GroupID: "foo", UserType: "GroupAdmin", Active: ptr.To(true), FullName: ptr.To("Baz")},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := isUpToDate("bar", tc.params, observed)
+			if got != tc.want {
+				t.Errorf("\n%s\nisUpToDate(...): want %t, got %t\n", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAddTemplateDetails(t *testing.T) {
 	type want struct {
-		o   managed.ExternalObservation
-		err error
+		details managed.ConnectionDetails
+		reason  xpv1.ConditionReason
+	}
+
+	cases := map[string]struct {
+		reason   string
+		template string
+		want     want
+	}{
+		"Rendered": {
+			reason:   "Templates should be rendered against the user and its published initial access key.",
+			template: "{{ .GroupID }}/{{ .UserID }}:{{ .CanonicalID }}:{{ .AccessKey }}",
+			want: want{
+				details: managed.ConnectionDetails{"accessKey": []byte("key"), "template": []byte("foo/bar:123:key")},
+				reason:  v1alpha1.ReasonTemplatesRendered,
+			},
+		},
+		"Error": {
+			reason:   "Templates that cannot be rendered should be reported by a condition, without failing.",
+			template: "{{ .Password }}",
+			want: want{
+				details: managed.ConnectionDetails{"accessKey": []byte("key")},
+				reason:  v1alpha1.ReasonTemplateError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.User{Spec: v1alpha1.UserSpec{ForProvider: v1alpha1.UserParameters{
+				GroupID:             "foo",
+				ConnectionTemplates: map[string]string{"template": tc.template},
+			}}}
+			cr.Status.AtProvider.CanonicalID = "123"
+			details := managed.ConnectionDetails{"accessKey": []byte("key")}
+			e := external{}
+
+			e.addTemplateDetails(cr, cloudian.GroupUserID{GroupID: "foo", UserID: "bar"}, details)
+			if diff := cmp.Diff(tc.want.details, details); diff != "" {
+				t.Errorf("\n%s\ne.addTemplateDetails(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if got := cr.GetCondition(v1alpha1.TypeConnectionTemplatesRendered).Reason; got != tc.want.reason {
+				t.Errorf("\n%s\ne.addTemplateDetails(...): want condition reason %q, got %q\n", tc.reason, tc.want.reason, got)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		requests []string
+		err      error
+		purge    *v1alpha1.PurgeObservation
+	}
+
+	cases := map[string]struct {
+		reason     string
+		purge      bool
+		confirm    string
+		purging    bool
+		objects    int64
+		accessKeys bool
+		initialKey string
+		want       want
+	}{
+		"NoAccessKeys": {
+			reason: "A user without access keys should be deleted.",
+			want:   want{requests: []string{"GET /user/credentials/list", "DELETE /user"}},
+		},
+		"AccessKeys": {
+			reason:     "A user with access keys should not be deleted without purgeOnDelete.",
+			accessKeys: true,
+			want: want{
+				requests: []string{"GET /user/credentials/list"},
+				err:      errors.New(errHasAccessKeys),
+			},
+		},
+		"InitialAccessKey": {
+			reason:     "The kept initial access key should be deleted with the user.",
+			accessKeys: true,
+			initialKey: "key",
+			want: want{requests: []string{
+				"GET /user/credentials/list",
+				"DELETE /user/credentials",
+				"DELETE /user",
+			}},
+		},
+		"PurgeNotConfirmed": {
+			reason:  "A user should not be purged unless the annotation confirms the user ID.",
+			purge:   true,
+			confirm: "other",
+			want: want{
+				err: errors.Errorf(errPurgeNotConfirmed, v1alpha1.AnnotationConfirmPurge, "bar"),
+			},
+		},
+		"PurgeStarted": {
+			reason:  "The purge should be started, and its progress reported, until the user has no data.",
+			purge:   true,
+			confirm: "bar",
+			objects: 10,
+			want: want{
+				requests: []string{"POST /user/purge", "GET /system/bytecount", "GET /system/objectcount"},
+				purge:    &v1alpha1.PurgeObservation{RemainingBytes: 10, RemainingObjects: 10},
+			},
+		},
+		"PurgeDone": {
+			reason:     "The access keys and user should be deleted once the purge is done.",
+			purge:      true,
+			confirm:    "bar",
+			purging:    true,
+			accessKeys: true,
+			want: want{
+				requests: []string{
+					"GET /system/bytecount",
+					"GET /system/objectcount",
+					"GET /user/credentials/list",
+					"DELETE /user/credentials",
+					"DELETE /user",
+				},
+				purge: &v1alpha1.PurgeObservation{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				switch r.URL.Path {
+				case "/system/bytecount", "/system/objectcount":
+					json.NewEncoder(w).Encode(tc.objects)
+				case "/user/credentials/list":
+					if !tc.accessKeys {
+						w.WriteHeader(http.StatusNoContent)
+						return
+					}
+					json.NewEncoder(w).Encode([]map[string]any{{"accessKey": "key", "secretKey": "secret", "active": true}})
+				}
+			}))
+			defer admin.Close()
+
+			cr := &v1alpha1.User{Spec: v1alpha1.UserSpec{ForProvider: v1alpha1.UserParameters{GroupID: "foo", PurgeOnDelete: tc.purge}}}
+			meta.SetExternalName(cr, "bar")
+			meta.AddAnnotations(cr, map[string]string{v1alpha1.AnnotationConfirmPurge: tc.confirm})
+			meta.AddAnnotations(cr, map[string]string{v1alpha1.AnnotationInitialAccessKey: tc.initialKey})
+			if tc.purging {
+				cr.Status.AtProvider.Purge = &v1alpha1.PurgeObservation{RemainingObjects: 1}
+			}
+			e := external{cloudianService: cloudian.NewClient(admin.URL, "")}
+
+			_, err := e.Delete(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.requests, requests); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want requests, +got requests:\n%s\n", tc.reason, diff)
+			}
+			ignoreTimes := cmpopts.IgnoreFields(v1alpha1.PurgeObservation{}, "StartTime", "LastUpdated")
+			if diff := cmp.Diff(tc.want.purge, cr.Status.AtProvider.Purge, ignoreTimes); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want purge, +got purge:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		requests   []string
+		details    managed.ConnectionDetails
+		initialKey string
 	}
 
 	cases := map[string]struct {
 		reason string
-		fields fields
-		args   args
+		policy v1alpha1.InitialAccessKeyPolicy
 		want   want
 	}{
-		// TODO: Add test cases.
+		"Delete": {
+			reason: "The initial access key should be deleted by default.",
+			want: want{
+				requests: []string{"PUT /user", "GET /user/credentials/list", "DELETE /user/credentials"},
+				details:  managed.ConnectionDetails{},
+			},
+		},
+		"Keep": {
+			reason: "The initial access key should be kept and recorded in an annotation.",
+			policy: v1alpha1.InitialAccessKeyKeep,
+			want: want{
+				requests:   []string{"PUT /user", "GET /user/credentials/list"},
+				details:    managed.ConnectionDetails{},
+				initialKey: "key",
+			},
+		},
+		"Publish": {
+			reason: "The initial access key should be kept and published.",
+			policy: v1alpha1.InitialAccessKeyPublish,
+			want: want{
+				requests: []string{"PUT /user", "GET /user/credentials/list"},
+				details: managed.ConnectionDetails{
+					"accessKey": []byte("key"),
+					"secretKey": []byte("secret"),
+					"endpoint":  []byte("https://s3.example.com"),
+				},
+				initialKey: "key",
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{cloudianService: nil}
-			got, err := e.Observe(tc.args.ctx, tc.args.mg)
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			var requests []string
+			admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				if r.URL.Path == "/user/credentials/list" {
+					json.NewEncoder(w).Encode([]map[string]any{{"accessKey": "key", "secretKey": "secret", "active": true}})
+				}
+			}))
+			defer admin.Close()
+
+			cr := &v1alpha1.User{Spec: v1alpha1.UserSpec{ForProvider: v1alpha1.UserParameters{GroupID: "foo", InitialAccessKeyPolicy: tc.policy}}}
+			meta.SetExternalName(cr, "bar")
+			e := external{
+				cloudianService: cloudian.NewClient(admin.URL, ""),
+				s3:              &apisv1alpha1.S3Config{Endpoint: "https://s3.example.com"},
 			}
-			if diff := cmp.Diff(tc.want.o, got); diff != "" {
-				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+
+			got, err := e.Create(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Create(...): %v\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.requests, requests); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want requests, +got requests:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.details, got.ConnectionDetails); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want details, +got details:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.initialKey, cr.GetAnnotations()[v1alpha1.AnnotationInitialAccessKey]); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want initial access key, +got initial access key:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	created := false
+	var passwords []string
+	admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/user":
+			created = true
+		case r.URL.Path == "/user" && !created:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/user":
+			json.NewEncoder(w).Encode(map[string]any{"groupId": "foo", "userId": "bar", "userType": "User", "active": "true"})
+		case r.URL.Path == "/user/password":
+			passwords = append(passwords, r.PostFormValue("password"))
+		case r.URL.Path == "/user/credentials/list":
+			json.NewEncoder(w).Encode([]map[string]any{{"accessKey": "key", "secretKey": "secret", "active": true}})
+		}
+	}))
+	defer admin.Close()
+
+	s := runtime.NewScheme()
+	if err := v1alpha1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := corev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bar-password", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("s3cret")},
+	}
+	cr := &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "bar"},
+		Spec: v1alpha1.UserSpec{ForProvider: v1alpha1.UserParameters{
+			GroupID:                "foo",
+			Active:                 ptr.To(true),
+			InitialAccessKeyPolicy: v1alpha1.InitialAccessKeyKeep,
+			PasswordSecretRef: &xpv1.SecretKeySelector{
+				SecretReference: xpv1.SecretReference{Name: "bar-password", Namespace: "default"},
+				Key:             "password",
+			},
+		}},
+	}
+	kube := fakeclient.NewClientBuilder().WithScheme(s).WithObjects(cr, secret).WithStatusSubresource(cr).Build()
+	e := &external{cloudianService: cloudian.NewClient(admin.URL, ""), kube: kube}
+	r := managed.NewReconciler(&fake.Manager{Client: kube, Scheme: s},
+		resource.ManagedKind(v1alpha1.UserGroupVersionKind),
+		managed.WithExternalConnecter(managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+			return e, nil
+		})))
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "bar"}}
+	// The first reconcile creates the user, the second sets its password, and
+	// the third observes that it is up to date.
+	for range 3 {
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("r.Reconcile(...): %v", err)
+		}
+	}
+
+	got := &v1alpha1.User{}
+	if err := kube.Get(context.Background(), req.NamespacedName, got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("key", got.Status.AtProvider.InitialAccessKey); diff != "" {
+		t.Errorf("r.Reconcile(...): -want initial access key, +got initial access key:\n%s\n", diff)
+	}
+	if diff := cmp.Diff([]string{"s3cret"}, passwords); diff != "" {
+		t.Errorf("r.Reconcile(...): -want passwords set, +got passwords set:\n%s\n", diff)
+	}
+}
+
+func TestReconcileObserveOnly(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		deleted bool
+	}{
+		"Drift": {
+			reason: "A User that is only observed should not be updated, even if it differs from Cloudian.",
+		},
+		"Deleted": {
+			reason:  "A User that is only observed should not be deleted from Cloudian when it is deleted.",
+			deleted: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					requests = append(requests, r.Method+" "+r.URL.Path)
+				}
+				switch r.URL.Path {
+				case "/user":
+					json.NewEncoder(w).Encode(map[string]any{"groupId": "foo", "userId": "bar", "userType": "User", "active": "true", "fullName": "Bar"})
+				case "/user/credentials/list":
+					json.NewEncoder(w).Encode([]map[string]any{})
+				}
+			}))
+			defer admin.Close()
+
+			s := runtime.NewScheme()
+			if err := v1alpha1.SchemeBuilder.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			cr := &v1alpha1.User{
+				ObjectMeta: metav1.ObjectMeta{Name: "bar", Finalizers: []string{"finalizer.managedresource.crossplane.io"}},
+				Spec: v1alpha1.UserSpec{
+					ResourceSpec: xpv1.ResourceSpec{ManagementPolicies: xpv1.ManagementPolicies{xpv1.ManagementActionObserve}, DeletionPolicy: xpv1.DeletionDelete},
+					ForProvider:  v1alpha1.UserParameters{GroupID: "foo", FullName: ptr.To("Baz")},
+				},
+			}
+			meta.SetExternalName(cr, "bar")
+			kube := fakeclient.NewClientBuilder().WithScheme(s).WithObjects(cr).WithStatusSubresource(cr).Build()
+			if tc.deleted {
+				if err := kube.Delete(context.Background(), cr); err != nil {
+					t.Fatal(err)
+				}
+			}
+			e := &external{cloudianService: cloudian.NewClient(admin.URL, ""), kube: kube}
+			r := managed.NewReconciler(&fake.Manager{Client: kube, Scheme: s},
+				resource.ManagedKind(v1alpha1.UserGroupVersionKind),
+				managed.WithExternalConnecter(managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+					return e, nil
+				})),
+				managed.WithManagementPolicies())
+
+			if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "bar"}}); err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): %v\n", tc.reason, err)
+			}
+			if len(requests) != 0 {
+				t.Errorf("\n%s\nr.Reconcile(...): unexpected requests %v\n", tc.reason, requests)
 			}
 		})
 	}
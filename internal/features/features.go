@@ -29,4 +29,9 @@ const (
 	// Management Policies. See the below design for more details.
 	// https://github.com/crossplane/crossplane/blob/master/design/design-doc-observe-only-resources.md
 	EnableAlphaManagementPolicies feature.Flag = "EnableAlphaManagementPolicies"
+
+	// EnableAlphaTenantMetrics enables alpha support for publishing the
+	// observed usage and quality of service limits of groups and users as
+	// Prometheus metrics.
+	EnableAlphaTenantMetrics feature.Flag = "EnableAlphaTenantMetrics"
 )
@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics publishes the observed usage and quality of service limits
+// of Cloudian groups and users as Prometheus metrics.
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+var (
+	tenantLabels = []string{"group", "user"}
+	limitLabels  = []string{"group", "user", "limit"}
+
+	storageBytesDesc = prometheus.NewDesc("cloudian_tenant_storage_bytes",
+		"Bytes stored by a group or user.", tenantLabels, nil)
+	objectsDesc = prometheus.NewDesc("cloudian_tenant_objects",
+		"Objects stored by a group or user.", tenantLabels, nil)
+	storageQuotaBytesDesc = prometheus.NewDesc("cloudian_tenant_storage_quota_bytes",
+		"Storage quota of a group or user, by hard or warning limit.", limitLabels, nil)
+	objectsQuotaDesc = prometheus.NewDesc("cloudian_tenant_objects_quota",
+		"Object quota of a group or user, by hard or warning limit.", limitLabels, nil)
+	storageHeadroomBytesDesc = prometheus.NewDesc("cloudian_tenant_storage_headroom_bytes",
+		"Bytes a group or user can store before reaching a limit.", limitLabels, nil)
+	objectsHeadroomDesc = prometheus.NewDesc("cloudian_tenant_objects_headroom",
+		"Objects a group or user can store before reaching a limit.", limitLabels, nil)
+)
+
+// DefaultTenants is the Tenants of the provider, which is registered with the
+// metrics registry when tenant metrics are enabled.
+var DefaultTenants = NewTenants()
+
+type tenant struct {
+	usage  *usage
+	limits *cloudian.QualityOfService
+}
+
+type usage struct {
+	storageBytes int64
+	objects      int64
+}
+
+// Tenants is a Prometheus collector of the usage and quality of service limits
+// observed for groups and users. A group is identified by a GroupUserID with
+// UserID "*". All methods are no-ops on a nil Tenants.
+type Tenants struct {
+	mu      sync.Mutex
+	tenants map[cloudian.GroupUserID]*tenant
+}
+
+// NewTenants returns an empty Tenants.
+func NewTenants() *Tenants {
+	return &Tenants{tenants: map[cloudian.GroupUserID]*tenant{}}
+}
+
+// SetUsage records the observed usage of a group or user.
+func (t *Tenants) SetUsage(guid cloudian.GroupUserID, storageBytes, objects int64) {
+	t.update(guid, func(tn *tenant) { tn.usage = &usage{storageBytes: storageBytes, objects: objects} })
+}
+
+// DeleteUsage forgets the usage of a group or user.
+func (t *Tenants) DeleteUsage(guid cloudian.GroupUserID) {
+	t.update(guid, func(tn *tenant) { tn.usage = nil })
+}
+
+// SetLimits records the observed quality of service limits of a group or
+// user.
+func (t *Tenants) SetLimits(guid cloudian.GroupUserID, qos cloudian.QualityOfService) {
+	t.update(guid, func(tn *tenant) { tn.limits = &qos })
+}
+
+// DeleteLimits forgets the quality of service limits of a group or user.
+func (t *Tenants) DeleteLimits(guid cloudian.GroupUserID) {
+	t.update(guid, func(tn *tenant) { tn.limits = nil })
+}
+
+func (t *Tenants) update(guid cloudian.GroupUserID, fn func(*tenant)) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tn, ok := t.tenants[guid]
+	if !ok {
+		tn = &tenant{}
+		t.tenants[guid] = tn
+	}
+	fn(tn)
+	if tn.usage == nil && tn.limits == nil {
+		delete(t.tenants, guid)
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (t *Tenants) Describe(ch chan<- *prometheus.Desc) {
+	ch <- storageBytesDesc
+	ch <- objectsDesc
+	ch <- storageQuotaBytesDesc
+	ch <- objectsQuotaDesc
+	ch <- storageHeadroomBytesDesc
+	ch <- objectsHeadroomDesc
+}
+
+// Collect implements prometheus.Collector.
+func (t *Tenants) Collect(ch chan<- prometheus.Metric) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for guid, tn := range t.tenants {
+		user := guid.UserID
+		if user == "*" {
+			user = ""
+		}
+		gauge := func(desc *prometheus.Desc, v int64, labels ...string) {
+			labels = append([]string{guid.GroupID, user}, labels...)
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(v), labels...)
+		}
+
+		if tn.usage != nil {
+			gauge(storageBytesDesc, tn.usage.storageBytes)
+			gauge(objectsDesc, tn.usage.objects)
+		}
+		if tn.limits == nil {
+			continue
+		}
+		for name, limits := range map[string]cloudian.QualityOfServiceLimits{
+			"hard":    tn.limits.Hard,
+			"warning": tn.limits.Warning,
+		} {
+			if kib := limits.StorageQuotaKiBs; kib != nil && *kib >= 0 {
+				gauge(storageQuotaBytesDesc, *kib*1024, name)
+				if tn.usage != nil {
+					gauge(storageHeadroomBytesDesc, *kib*1024-tn.usage.storageBytes, name)
+				}
+			}
+			if count := limits.StorageQuotaCount; count != nil && *count >= 0 {
+				gauge(objectsQuotaDesc, *count, name)
+				if tn.usage != nil {
+					gauge(objectsHeadroomDesc, *count-tn.usage.objects, name)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/ptr"
+
+	"github.com/statnett/provider-cloudian/internal/sdk/cloudian"
+)
+
+func TestTenants(t *testing.T) {
+	group := cloudian.GroupUserID{GroupID: "QA", UserID: "*"}
+	user := cloudian.GroupUserID{GroupID: "QA", UserID: "alice"}
+	removed := cloudian.GroupUserID{GroupID: "Prod", UserID: "*"}
+
+	tenants := NewTenants()
+	tenants.SetUsage(group, 3<<30, 900)
+	tenants.SetLimits(group, cloudian.QualityOfService{
+		Hard:    cloudian.QualityOfServiceLimits{StorageQuotaKiBs: ptr.To[int64](4 << 20), StorageQuotaCount: ptr.To[int64](1000)},
+		Warning: cloudian.QualityOfServiceLimits{StorageQuotaKiBs: ptr.To[int64](2 << 20), StorageQuotaCount: ptr.To[int64](-1)},
+	})
+	tenants.SetUsage(user, 1024, 1)
+	tenants.SetUsage(removed, 1, 1)
+	tenants.DeleteUsage(removed)
+
+	expected := `
+# HELP cloudian_tenant_objects Objects stored by a group or user.
+# TYPE cloudian_tenant_objects gauge
+cloudian_tenant_objects{group="QA",user=""} 900
+cloudian_tenant_objects{group="QA",user="alice"} 1
+# HELP cloudian_tenant_objects_headroom Objects a group or user can store before reaching a limit.
+# TYPE cloudian_tenant_objects_headroom gauge
+cloudian_tenant_objects_headroom{group="QA",limit="hard",user=""} 100
+# HELP cloudian_tenant_objects_quota Object quota of a group or user, by hard or warning limit.
+# TYPE cloudian_tenant_objects_quota gauge
+cloudian_tenant_objects_quota{group="QA",limit="hard",user=""} 1000
+# HELP cloudian_tenant_storage_bytes Bytes stored by a group or user.
+# TYPE cloudian_tenant_storage_bytes gauge
+cloudian_tenant_storage_bytes{group="QA",user=""} 3.221225472e+09
+cloudian_tenant_storage_bytes{group="QA",user="alice"} 1024
+# HELP cloudian_tenant_storage_headroom_bytes Bytes a group or user can store before reaching a limit.
+# TYPE cloudian_tenant_storage_headroom_bytes gauge
+cloudian_tenant_storage_headroom_bytes{group="QA",limit="hard",user=""} 1.073741824e+09
+cloudian_tenant_storage_headroom_bytes{group="QA",limit="warning",user=""} -1.073741824e+09
+# HELP cloudian_tenant_storage_quota_bytes Storage quota of a group or user, by hard or warning limit.
+# TYPE cloudian_tenant_storage_quota_bytes gauge
+cloudian_tenant_storage_quota_bytes{group="QA",limit="hard",user=""} 4.294967296e+09
+cloudian_tenant_storage_quota_bytes{group="QA",limit="warning",user=""} 2.147483648e+09
+`
+	if err := testutil.CollectAndCompare(tenants, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNilTenants(t *testing.T) {
+	var tenants *Tenants
+	tenants.SetUsage(cloudian.GroupUserID{GroupID: "QA", UserID: "*"}, 1, 1)
+	tenants.DeleteLimits(cloudian.GroupUserID{GroupID: "QA", UserID: "*"})
+}
@@ -0,0 +1,108 @@
+package cloudian
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ErrCircuitOpen is returned without making a request while the circuit
+// breaker of a client is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open: Cloudian API is failing")
+
+// circuitBreaker fails requests fast after a number of consecutive requests
+// failed. Once open, a single probe request is let through after a cooldown,
+// which closes the circuit if it succeeds and opens it again if it fails.
+type circuitBreaker struct {
+	failureThreshold int
+	openDuration     time.Duration
+	now              func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	// probe is the request let through while the circuit is half open.
+	probe *resty.Request
+}
+
+func newCircuitBreaker(failureThreshold int, openDuration time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		failureThreshold: failureThreshold,
+		openDuration:     openDuration,
+		now:              time.Now,
+	}
+}
+
+// install makes the circuit breaker guard every request of a resty client.
+func (b *circuitBreaker) install(c *resty.Client) {
+	c.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		return b.allow(req)
+	})
+	c.OnSuccess(func(_ *resty.Client, resp *resty.Response) {
+		b.record(resp.Request, resp.StatusCode() >= 500)
+	})
+	c.OnError(func(req *resty.Request, err error) {
+		if errors.Is(err, ErrCircuitOpen) || errors.Is(err, ErrRateLimited) || errors.Is(err, context.Canceled) {
+			// The request says nothing about the health of the API, but if it
+			// was the probe, another request must be let through instead.
+			b.release(req)
+			return
+		}
+		var netErr net.Error
+		b.record(req, errors.As(err, &netErr) || statusCode(err) >= 500)
+	})
+}
+
+// allow returns ErrCircuitOpen if a request must fail fast. It is called for
+// each attempt of a request, so retries of the probe are let through.
+func (b *circuitBreaker) allow(req *resty.Request) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case b.failures < b.failureThreshold:
+		return nil
+	case b.probe == req:
+		return nil
+	case b.probe == nil && b.now().Sub(b.openedAt) >= b.openDuration:
+		b.probe = req
+		return nil
+	default:
+		return ErrCircuitOpen
+	}
+}
+
+// release lets another request probe the API if req was the probe, without
+// counting it as a success or failure.
+func (b *circuitBreaker) release(req *resty.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.probe == req {
+		b.probe = nil
+	}
+}
+
+func (b *circuitBreaker) record(req *resty.Request, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.probe != nil && b.probe != req {
+		// A request sent before the circuit opened; only the probe decides.
+		return
+	}
+	b.probe = nil
+
+	if !failed {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.failureThreshold {
+		b.openedAt = b.now()
+	}
+}
@@ -0,0 +1,227 @@
+package cloudian
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// EndpointPolicy selects the endpoint of a request among healthy endpoints.
+type EndpointPolicy string
+
+const (
+	// EndpointPolicyPriority sends requests to the first healthy endpoint. It
+	// is the policy of an empty EndpointPolicy.
+	EndpointPolicyPriority EndpointPolicy = "Priority"
+	// EndpointPolicyRoundRobin spreads requests over all healthy endpoints.
+	EndpointPolicyRoundRobin EndpointPolicy = "RoundRobin"
+)
+
+// endpointRecheckInterval is how long an unhealthy endpoint is avoided before
+// requests are sent to it again.
+const endpointRecheckInterval = 30 * time.Second
+
+// EndpointStatus is the health of an endpoint, as last observed by a client.
+type EndpointStatus struct {
+	URL     string
+	Healthy bool
+	// LastError is the error of the last failed request to the endpoint, if
+	// it is unhealthy.
+	LastError string
+	// LastTransition is when the endpoint last became healthy or unhealthy.
+	LastTransition time.Time
+}
+
+// endpointPool fails over requests between endpoints. Endpoints are marked
+// unhealthy by requests that fail with a connection error or 5xx response,
+// and healthy by any other response.
+type endpointPool struct {
+	policy EndpointPolicy
+	now    func() time.Time
+
+	mu        sync.Mutex
+	endpoints []*endpoint
+	next      int
+}
+
+type endpoint struct {
+	EndpointStatus
+	lastFailure time.Time
+}
+
+func newEndpointPool(urls []string, policy EndpointPolicy) *endpointPool {
+	p := &endpointPool{policy: policy, now: time.Now}
+	for _, u := range urls {
+		p.endpoints = append(p.endpoints, &endpoint{EndpointStatus: EndpointStatus{URL: strings.TrimSuffix(u, "/"), Healthy: true}})
+	}
+	return p
+}
+
+// install makes a resty client send each request to an endpoint of the pool.
+// Retries of a request pick an endpoint anew, so they fail over.
+func (p *endpointPool) install(c *resty.Client) {
+	c.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		if strings.HasPrefix(req.URL, "/") {
+			req.URL = p.pick() + req.URL
+		}
+		return nil
+	})
+	c.AddRetryHook(func(resp *resty.Response, err error) {
+		if resp != nil {
+			p.record(resp.Request.URL, err, resp.StatusCode())
+		}
+	})
+	c.OnSuccess(func(_ *resty.Client, resp *resty.Response) {
+		p.record(resp.Request.URL, nil, resp.StatusCode())
+	})
+	c.OnError(func(req *resty.Request, err error) {
+		if !errors.Is(err, ErrCircuitOpen) && !errors.Is(err, ErrRateLimited) && !errors.Is(err, context.Canceled) {
+			p.record(req.URL, err, statusCode(err))
+		}
+	})
+}
+
+// pick returns the endpoint of the next request.
+func (p *endpointPool) pick() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e := p.choose()
+	if p.policy == EndpointPolicyRoundRobin {
+		p.next++
+	}
+	return e.URL
+}
+
+// peek returns the endpoint of the next request without advancing the round
+// robin.
+func (p *endpointPool) peek() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.choose().URL
+}
+
+// choose returns the endpoint of the next request. Unhealthy endpoints are only
+// chosen once they have been avoided for a while, or if all are unhealthy. It
+// must be called with the lock held.
+func (p *endpointPool) choose() *endpoint {
+	now := p.now()
+	var eligible []*endpoint
+	for _, e := range p.endpoints {
+		if e.Healthy || now.Sub(e.lastFailure) >= endpointRecheckInterval {
+			eligible = append(eligible, e)
+		}
+	}
+	if len(eligible) == 0 {
+		// All endpoints are unhealthy, so try the one that failed first.
+		oldest := p.endpoints[0]
+		for _, e := range p.endpoints[1:] {
+			if e.lastFailure.Before(oldest.lastFailure) {
+				oldest = e
+			}
+		}
+		return oldest
+	}
+
+	if p.policy == EndpointPolicyRoundRobin {
+		return eligible[p.next%len(eligible)]
+	}
+	return eligible[0]
+}
+
+// record updates the health of the endpoint a request was sent to.
+func (p *endpointPool) record(url string, err error, status int) {
+	var netErr net.Error
+	failed := errors.As(err, &netErr) || status >= 500
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, e := range p.endpoints {
+		if !strings.HasPrefix(url, e.URL+"/") {
+			continue
+		}
+		now := p.now()
+		if failed {
+			e.lastFailure = now
+			e.LastError = describeFailure(err, status)
+		} else {
+			e.LastError = ""
+		}
+		if e.Healthy == failed {
+			e.Healthy = !failed
+			e.LastTransition = now
+		}
+		return
+	}
+}
+
+func describeFailure(err error, status int) string {
+	if err != nil {
+		return err.Error()
+	}
+	return "unexpected status: " + strconv.Itoa(status)
+}
+
+// statuses returns the health of all endpoints, in order.
+func (p *endpointPool) statuses() []EndpointStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s := make([]EndpointStatus, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		s = append(s, e.EndpointStatus)
+	}
+	return s
+}
+
+// WithEndpoints sends requests to a pool of equivalent Cloudian API endpoints
+// instead of the base URL. Requests fail over to the other endpoints while an
+// endpoint fails with connection errors or 5xx responses.
+func WithEndpoints(urls []string, policy EndpointPolicy) func(*Client) {
+	return func(c *Client) {
+		if len(urls) == 0 {
+			return
+		}
+		c.endpoints = newEndpointPool(urls, policy)
+		c.endpoints.install(c.client)
+	}
+}
+
+// Endpoints returns the health of the endpoints of the client, as observed by
+// its requests, or nil if the client has no endpoint pool.
+func (client Client) Endpoints() []EndpointStatus {
+	if client.endpoints == nil {
+		return nil
+	}
+	return client.endpoints.statuses()
+}
+
+// ActiveEndpoint returns the endpoint the next request is sent to, or an empty
+// string if the client has no endpoint pool.
+func (client Client) ActiveEndpoint() string {
+	if client.endpoints == nil {
+		return ""
+	}
+	return client.endpoints.peek()
+}
+
+// CheckEndpoints requests the version of each endpoint of the client, so that
+// Endpoints reflects whether they are all reachable.
+func (client Client) CheckEndpoints(ctx context.Context) []EndpointStatus {
+	if client.endpoints == nil {
+		return nil
+	}
+	for _, e := range client.endpoints.statuses() {
+		// Errors are recorded in the endpoint pool.
+		_, _ = client.newRequest(ctx).Get(e.URL + "/system/version")
+	}
+	return client.endpoints.statuses()
+}
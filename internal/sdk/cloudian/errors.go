@@ -0,0 +1,121 @@
+package cloudian
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+var ErrNotFound = errors.New("not found")
+
+// maxErrorMessageLength limits how much of an unstructured response body is
+// kept as the message of an APIError.
+const maxErrorMessageLength = 512
+
+// APIError is returned when the Cloudian API responds with an unexpected
+// status code.
+type APIError struct {
+	// Operation is what the SDK tried to do, like "CREATE user".
+	Operation  string
+	Method     string
+	Path       string
+	StatusCode int
+	// Message is the error message of the response body, if any.
+	Message string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s unexpected status: %d (%s %s)", e.Operation, e.StatusCode, e.Method, e.Path)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// Is makes errors.Is(err, ErrNotFound) true for a 404 Not Found response.
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// newAPIError describes an unexpected response to an operation.
+func newAPIError(operation string, resp *resty.Response) *APIError {
+	e := &APIError{
+		Operation:  operation,
+		Method:     resp.Request.Method,
+		StatusCode: resp.StatusCode(),
+		Message:    errorMessage(resp.Body()),
+	}
+	if raw := resp.Request.RawRequest; raw != nil {
+		e.Path = raw.URL.Path
+	}
+	return e
+}
+
+// errorMessage decodes the message of an error response body, which Cloudian
+// sends either as JSON or as plain text.
+func errorMessage(body []byte) string {
+	var structured struct {
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &structured); err == nil {
+		if structured.Message != "" {
+			return structured.Message
+		}
+		if structured.Error != "" {
+			return structured.Error
+		}
+	}
+
+	msg := strings.TrimSpace(string(body))
+	if len(msg) > maxErrorMessageLength {
+		msg = msg[:maxErrorMessageLength] + "..."
+	}
+	return msg
+}
+
+// statusCode returns the status code of an APIError in the chain of err, or
+// zero if there is none.
+func statusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsConflict reports whether err is a 409 Conflict response, which Cloudian
+// returns when a resource already exists or is still in use.
+func IsConflict(err error) bool {
+	return statusCode(err) == http.StatusConflict
+}
+
+// IsUnauthorized reports whether err is a 401 Unauthorized response, meaning
+// the credentials of the client were rejected.
+func IsUnauthorized(err error) bool {
+	return statusCode(err) == http.StatusUnauthorized
+}
+
+// IsForbidden reports whether err is a 403 Forbidden response, meaning the
+// user of the client may not perform the operation.
+func IsForbidden(err error) bool {
+	return statusCode(err) == http.StatusForbidden
+}
+
+// IsRetryable reports whether the request that caused err may succeed if
+// retried unchanged: on a network error, an open circuit breaker, 429 Too Many
+// Requests, or a 5xx response other than 501 Not Implemented.
+func IsRetryable(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, ErrCircuitOpen) {
+		return true
+	}
+	code := statusCode(err)
+	return code == http.StatusTooManyRequests ||
+		code >= 500 && code != http.StatusNotImplemented
+}
@@ -0,0 +1,148 @@
+package cloudian
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// UserStatus filters users by whether they are active.
+type UserStatus string
+
+const (
+	UserStatusActive   UserStatus = "active"
+	UserStatusInactive UserStatus = "inactive"
+)
+
+// ListUsersOptions filters the users listed by ListUsers. Unset fields do not
+// filter.
+type ListUsersOptions struct {
+	UserType   UserType
+	UserStatus UserStatus
+	// Prefix filters users whose ID starts with it.
+	Prefix string
+	// PageSize is how many users are requested at once, ListLimit if zero.
+	PageSize int
+}
+
+// ListGroupsOptions filters the groups listed by ListGroups. Unset fields do
+// not filter.
+type ListGroupsOptions struct {
+	// Prefix filters groups whose ID starts with it.
+	Prefix string
+	// PageSize is how many groups are requested at once, ListLimit if zero.
+	PageSize int
+}
+
+// ListUsers iterates over the users of a group, fetching a page at a time.
+// Iteration stops after the first error.
+func (client Client) ListUsers(ctx context.Context, groupID string, opts ListUsersOptions) iter.Seq2[User, error] {
+	params := map[string]string{
+		"groupId":    groupID,
+		"userType":   "all",
+		"userStatus": "all",
+	}
+	if opts.UserType != "" {
+		params["userType"] = string(opts.UserType)
+	}
+	if opts.UserStatus != "" {
+		params["userStatus"] = string(opts.UserStatus)
+	}
+	if opts.Prefix != "" {
+		params["prefix"] = opts.Prefix
+	}
+
+	return paginate(opts.PageSize, func(offset string, limit int) ([]User, error) {
+		var usersInternal []userInternal
+		resp, err := client.listRequest(ctx, params, offset, limit).
+			SetResult(&usersInternal).
+			Get("/user/list")
+		if err != nil {
+			return nil, fmt.Errorf("GET list users failed: %w", err)
+		}
+		if resp.IsError() {
+			return nil, newAPIError("LIST users", resp)
+		}
+
+		users := make([]User, 0, len(usersInternal))
+		for _, u := range usersInternal {
+			users = append(users, userFromInternal(u))
+		}
+		return users, nil
+	}, func(u User) string { return u.UserID })
+}
+
+// ListGroups iterates over all groups, fetching a page at a time. Iteration
+// stops after the first error.
+func (client Client) ListGroups(ctx context.Context, opts ListGroupsOptions) iter.Seq2[Group, error] {
+	params := map[string]string{}
+	if opts.Prefix != "" {
+		params["prefix"] = opts.Prefix
+	}
+
+	return paginate(opts.PageSize, func(offset string, limit int) ([]Group, error) {
+		var groupsInternal []groupInternal
+		resp, err := client.listRequest(ctx, params, offset, limit).
+			SetResult(&groupsInternal).
+			Get("/group/list")
+		if err != nil {
+			return nil, fmt.Errorf("GET list groups failed: %w", err)
+		}
+		if resp.IsError() {
+			return nil, newAPIError("LIST groups", resp)
+		}
+
+		groups := make([]Group, 0, len(groupsInternal))
+		for _, g := range groupsInternal {
+			groups = append(groups, fromInternal(g))
+		}
+		return groups, nil
+	}, func(g Group) string { return g.GroupID })
+}
+
+func (client Client) listRequest(ctx context.Context, params map[string]string, offset string, limit int) *resty.Request {
+	req := client.newRequest(ctx).
+		SetQueryParams(params).
+		SetQueryParam("limit", strconv.Itoa(limit))
+	if offset != "" {
+		req.SetQueryParam("offset", offset)
+	}
+	return req
+}
+
+// paginate iterates over the pages of a Cloudian list endpoint. The endpoints
+// return one item more than the limit when there are more pages, and the ID of
+// that item is the offset of the next page, which starts with it.
+func paginate[T any](pageSize int, fetch func(offset string, limit int) ([]T, error), id func(T) string) iter.Seq2[T, error] {
+	if pageSize <= 0 {
+		pageSize = ListLimit
+	}
+	return func(yield func(T, error) bool) {
+		offset := ""
+		for {
+			page, err := fetch(offset, pageSize)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			more := len(page) > pageSize
+			if more {
+				offset = id(page[pageSize])
+				page = page[:pageSize]
+			}
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !more {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package cloudian
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cloudian_api_requests_total",
+		Help: "Number of requests to the Cloudian Admin API, by method, endpoint and status code.",
+	}, []string{"method", "endpoint", "status"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "cloudian_api_request_duration_seconds",
+		Help:    "Latency of requests to the Cloudian Admin API, by method and endpoint.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "endpoint"})
+)
+
+// Collectors returns the Prometheus collectors of the requests of all
+// Cloudian clients, for registration with a metrics registry.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{requestsTotal, requestDuration}
+}
+
+// instrument records the outcome of every request of a resty client.
+// Requests that fail without a response are counted with status "error", or
+// "circuit_open" and "rate_limited" if they were not sent because the circuit
+// breaker is open or the rate limit was exceeded.
+func instrument(c *resty.Client) {
+	c.OnSuccess(func(_ *resty.Client, resp *resty.Response) {
+		observeRequest(resp.Request, strconv.Itoa(resp.StatusCode()), resp.Time())
+	})
+	c.OnError(func(req *resty.Request, err error) {
+		status := "error"
+		var respErr *resty.ResponseError
+		switch {
+		case errors.Is(err, ErrCircuitOpen):
+			status = "circuit_open"
+		case errors.Is(err, ErrRateLimited):
+			status = "rate_limited"
+		case errors.As(err, &respErr) && respErr.Response != nil && respErr.Response.RawResponse != nil:
+			status = strconv.Itoa(respErr.Response.StatusCode())
+		}
+		observeRequest(req, status, time.Since(req.Time))
+	})
+}
+
+func observeRequest(req *resty.Request, status string, d time.Duration) {
+	// Identifiers are passed as query parameters, so the path is a bounded
+	// label value.
+	endpoint := "unknown"
+	if req.RawRequest != nil {
+		endpoint = req.RawRequest.URL.Path
+	} else if u, err := url.Parse(req.URL); err == nil {
+		endpoint = u.Path
+	}
+	requestsTotal.WithLabelValues(req.Method, endpoint, status).Inc()
+	requestDuration.WithLabelValues(req.Method, endpoint).Observe(d.Seconds())
+}
@@ -0,0 +1,29 @@
+package cloudian
+
+import (
+	"context"
+)
+
+// PurgeUser starts deleting all buckets and objects of a user. The purge runs
+// in the background; its progress can be followed with GetStoredUsage.
+func (client Client) PurgeUser(ctx context.Context, guid GroupUserID) error {
+	resp, err := client.newRequest(ctx).
+		SetQueryParams(map[string]string{
+			"groupId": guid.GroupID,
+			"userId":  guid.UserID,
+		}).
+		Post("/user/purge")
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return nil
+	case 204:
+		// Cloudian-API returns 204 if the user does not exist
+		return ErrNotFound
+	default:
+		return newAPIError("PURGE user", resp)
+	}
+}
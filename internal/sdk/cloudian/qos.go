@@ -173,7 +173,7 @@ func (client Client) SetQOS(ctx context.Context, guid GroupUserID, region string
 	case 200:
 		return nil
 	default:
-		return fmt.Errorf("POST quota unexpected status: %d", resp.StatusCode())
+		return newAPIError("SET QoS limits", resp)
 	}
 }
 
@@ -212,7 +212,7 @@ func (client Client) GetQOS(ctx context.Context, guid GroupUserID, region string
 
 		return qos, nil
 	default:
-		return nil, fmt.Errorf("GET quota unexpected status: %d", resp.StatusCode())
+		return nil, newAPIError("GET QoS limits", resp)
 	}
 }
 
@@ -237,6 +237,6 @@ func (client Client) DeleteQOS(ctx context.Context, guid GroupUserID, region str
 	case 200:
 		return nil
 	default:
-		return fmt.Errorf("DELETE quota unexpected status: %d", resp.StatusCode())
+		return newAPIError("DELETE QoS limits", resp)
 	}
 }
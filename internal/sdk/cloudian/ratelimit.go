@@ -0,0 +1,28 @@
+package cloudian
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+	"golang.org/x/time/rate"
+)
+
+// ErrRateLimited is returned without making a request when a request would
+// have to wait for the rate limiter of a client beyond its context deadline.
+var ErrRateLimited = errors.New("client-side rate limit of the Cloudian API exceeded")
+
+// WithRateLimit limits the requests of the client to `requestsPerSecond` on
+// average, with bursts of up to `burst` requests. Requests wait for their turn
+// until their context is done. Each attempt of a retried request counts.
+func WithRateLimit(requestsPerSecond float64, burst int) func(*Client) {
+	return func(c *Client) {
+		limiter := rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+		c.client.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+			if err := limiter.Wait(req.Context()); err != nil {
+				return fmt.Errorf("%w: %w", ErrRateLimited, err)
+			}
+			return nil
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package cloudian
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RatingPlan prices the usage of the groups and users it is assigned to, for
+// chargeback.
+type RatingPlan struct {
+	ID       string
+	Name     string
+	Currency string
+	// Storage is priced per GB-month of stored data.
+	Storage []RatingTier
+	// DataTransferIn and DataTransferOut are priced per GB transferred.
+	DataTransferIn  []RatingTier
+	DataTransferOut []RatingTier
+	// GetRequests, PutRequests and DeleteRequests are priced per 10,000
+	// requests.
+	GetRequests    []RatingTier
+	PutRequests    []RatingTier
+	DeleteRequests []RatingTier
+}
+
+// RatingTier prices usage up to an amount at a price per unit. The last tier of
+// a rating has no upper bound.
+type RatingTier struct {
+	// UpTo is the upper bound of the tier in units, or 0 for no upper bound.
+	UpTo int64
+	// Price is the decimal price per unit.
+	Price string
+}
+
+// Rule classes of a rating plan.
+const (
+	ruleClassStorage         = "SB"
+	ruleClassDataTransferIn  = "BI"
+	ruleClassDataTransferOut = "BO"
+	ruleClassGetRequests     = "HG"
+	ruleClassPutRequests     = "HP"
+	ruleClassDeleteRequests  = "HD"
+)
+
+// ratingPlanInternal is the SDK's internal representation of a Cloudian
+// rating plan.
+type ratingPlanInternal struct {
+	ID          string              `json:"id"`
+	Name        string              `json:"name"`
+	Currency    string              `json:"currency"`
+	RuleClasses []ruleClassInternal `json:"ruleClasses"`
+}
+
+// ruleClassInternal rates one kind of usage. Rules are comma separated upper
+// bound and price pairs, separated by colons, like "1000,0.05:0,0.04".
+type ruleClassInternal struct {
+	RuleClassType string `json:"ruleclassType"`
+	Rules         string `json:"rules"`
+}
+
+func (p RatingPlan) ruleClasses() map[string][]RatingTier {
+	return map[string][]RatingTier{
+		ruleClassStorage:         p.Storage,
+		ruleClassDataTransferIn:  p.DataTransferIn,
+		ruleClassDataTransferOut: p.DataTransferOut,
+		ruleClassGetRequests:     p.GetRequests,
+		ruleClassPutRequests:     p.PutRequests,
+		ruleClassDeleteRequests:  p.DeleteRequests,
+	}
+}
+
+func ratingPlanToInternal(p RatingPlan) ratingPlanInternal {
+	plan := ratingPlanInternal{
+		ID:       p.ID,
+		Name:     p.Name,
+		Currency: p.Currency,
+	}
+	classes := p.ruleClasses()
+	for _, class := range []string{
+		ruleClassStorage, ruleClassDataTransferIn, ruleClassDataTransferOut,
+		ruleClassGetRequests, ruleClassPutRequests, ruleClassDeleteRequests,
+	} {
+		tiers := classes[class]
+		if len(tiers) == 0 {
+			continue
+		}
+		rules := make([]string, 0, len(tiers))
+		for _, t := range tiers {
+			rules = append(rules, strconv.FormatInt(t.UpTo, 10)+","+t.Price)
+		}
+		plan.RuleClasses = append(plan.RuleClasses, ruleClassInternal{RuleClassType: class, Rules: strings.Join(rules, ":")})
+	}
+	return plan
+}
+
+func ratingPlanFromInternal(p ratingPlanInternal) (RatingPlan, error) {
+	plan := RatingPlan{
+		ID:       p.ID,
+		Name:     p.Name,
+		Currency: p.Currency,
+	}
+	for _, rc := range p.RuleClasses {
+		var tiers []RatingTier
+		for _, rule := range strings.Split(rc.Rules, ":") {
+			upTo, price, ok := strings.Cut(rule, ",")
+			if !ok {
+				return RatingPlan{}, fmt.Errorf("invalid %s rule: %q", rc.RuleClassType, rule)
+			}
+			u, err := strconv.ParseInt(upTo, 10, 64)
+			if err != nil {
+				return RatingPlan{}, fmt.Errorf("invalid %s rule: %w", rc.RuleClassType, err)
+			}
+			tiers = append(tiers, RatingTier{UpTo: u, Price: price})
+		}
+
+		switch rc.RuleClassType {
+		case ruleClassStorage:
+			plan.Storage = tiers
+		case ruleClassDataTransferIn:
+			plan.DataTransferIn = tiers
+		case ruleClassDataTransferOut:
+			plan.DataTransferOut = tiers
+		case ruleClassGetRequests:
+			plan.GetRequests = tiers
+		case ruleClassPutRequests:
+			plan.PutRequests = tiers
+		case ruleClassDeleteRequests:
+			plan.DeleteRequests = tiers
+		}
+	}
+	return plan, nil
+}
+
+// GetRatingPlan gets a rating plan. Returns an error even in the case of a
+// rating plan not found. This error can then be checked against ErrNotFound.
+func (client Client) GetRatingPlan(ctx context.Context, id string) (*RatingPlan, error) {
+	var plan ratingPlanInternal
+	resp, err := client.newRequest(ctx).
+		SetQueryParam("ratingPlanId", id).
+		SetResult(&plan).
+		Get("/ratingPlan")
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		retVal, err := ratingPlanFromInternal(plan)
+		if err != nil {
+			return nil, err
+		}
+		return &retVal, nil
+	case 204:
+		// Cloudian-API returns 204 if the rating plan does not exist
+		return nil, ErrNotFound
+	default:
+		return nil, newAPIError("GET rating plan", resp)
+	}
+}
+
+// CreateRatingPlan creates a rating plan.
+func (client Client) CreateRatingPlan(ctx context.Context, plan RatingPlan) error {
+	resp, err := client.newRequest(ctx).
+		SetBody(ratingPlanToInternal(plan)).
+		Put("/ratingPlan")
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return nil
+	default:
+		return newAPIError("CREATE rating plan", resp)
+	}
+}
+
+// UpdateRatingPlan updates a rating plan.
+func (client Client) UpdateRatingPlan(ctx context.Context, plan RatingPlan) error {
+	resp, err := client.newRequest(ctx).
+		SetBody(ratingPlanToInternal(plan)).
+		Post("/ratingPlan")
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return nil
+	default:
+		return newAPIError("UPDATE rating plan", resp)
+	}
+}
+
+// DeleteRatingPlan deletes a rating plan that is not assigned to any group or
+// user.
+func (client Client) DeleteRatingPlan(ctx context.Context, id string) error {
+	resp, err := client.newRequest(ctx).
+		SetQueryParam("ratingPlanId", id).
+		Delete("/ratingPlan")
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return nil
+	case 204:
+		return ErrNotFound
+	default:
+		return newAPIError("DELETE rating plan", resp)
+	}
+}
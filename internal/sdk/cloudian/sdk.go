@@ -3,17 +3,29 @@ package cloudian
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"slices"
 	"strconv"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 )
 
+// ListLimit is the default page size of list requests.
 const ListLimit = 100
 
 type Client struct {
 	client *resty.Client
+	// s3HTTPClient is the HTTP client of the S3 service, which has a TLS
+	// configuration of its own.
+	s3HTTPClient *http.Client
+	// endpoints is the pool of endpoints requests are sent to, if the client
+	// has several.
+	endpoints *endpointPool
 }
 
 type Group struct {
@@ -27,6 +39,7 @@ type Group struct {
 	LDAPSearchUserBase string `json:"ldapSearchUserBase"`
 	LDAPServerURL      string `json:"ldapServerURL"`
 	LDAPUserDNTemplate string `json:"ldapUserDNTemplate"`
+	RatingPlanID       string `json:"ratingPlanId"`
 }
 
 // groupInternal is the SDK's internal representation of a cloudion group.
@@ -42,6 +55,7 @@ type groupInternal struct {
 	LDAPSearchUserBase string   `json:"ldapSearchUserBase"`
 	LDAPServerURL      string   `json:"ldapServerURL"`
 	LDAPUserDNTemplate string   `json:"ldapUserDNTemplate"`
+	RatingPlanID       string   `json:"ratingPlanId,omitempty"`
 	S3EndpointsHTTP    []string `json:"s3endpointshttp"`
 	S3EndpointsHTTPS   []string `json:"s3endpointshttps"`
 	S3WebSiteEndpoints []string `json:"s3websiteendpoints"`
@@ -66,6 +80,7 @@ func toInternal(g Group) groupInternal {
 		LDAPSearchUserBase: g.LDAPSearchUserBase,
 		LDAPServerURL:      g.LDAPServerURL,
 		LDAPUserDNTemplate: g.LDAPUserDNTemplate,
+		RatingPlanID:       g.RatingPlanID,
 		S3EndpointsHTTP:    []string{"ALL"},
 		S3EndpointsHTTPS:   []string{"ALL"},
 		S3WebSiteEndpoints: []string{"ALL"},
@@ -84,6 +99,7 @@ func fromInternal(g groupInternal) Group {
 		LDAPSearchUserBase: g.LDAPSearchUserBase,
 		LDAPServerURL:      g.LDAPServerURL,
 		LDAPUserDNTemplate: g.LDAPUserDNTemplate,
+		RatingPlanID:       g.RatingPlanID,
 	}
 }
 
@@ -101,71 +117,218 @@ type GroupUserID struct {
 }
 
 type User struct {
-	GroupUserID `json:",inline"`
-	UserType    UserType `json:"userType"`
-	CanonicalID string   `json:"canonicalUserId,omitempty"`
+	GroupUserID  `json:",inline"`
+	UserType     UserType `json:"userType"`
+	CanonicalID  string   `json:"canonicalUserId,omitempty"`
+	Active       bool     `json:"active"`
+	FullName     string   `json:"fullName"`
+	EmailAddr    string   `json:"emailAddr"`
+	Address1     string   `json:"address1"`
+	Address2     string   `json:"address2"`
+	City         string   `json:"city"`
+	State        string   `json:"state"`
+	Zip          string   `json:"zip"`
+	Country      string   `json:"country"`
+	Phone        string   `json:"phone"`
+	LDAPEnabled  bool     `json:"ldapEnabled"`
+	RatingPlanID string   `json:"ratingPlanId"`
+}
+
+// userInternal is the SDK's internal representation of a cloudian user.
+// Fields must be exported (uppercase) to allow json marshalling.
+type userInternal struct {
+	GroupUserID  `json:",inline"`
+	UserType     UserType `json:"userType"`
+	CanonicalID  string   `json:"canonicalUserId,omitempty"`
+	Active       string   `json:"active"`
+	FullName     string   `json:"fullName"`
+	EmailAddr    string   `json:"emailAddr"`
+	Address1     string   `json:"address1"`
+	Address2     string   `json:"address2"`
+	City         string   `json:"city"`
+	State        string   `json:"state"`
+	Zip          string   `json:"zip"`
+	Country      string   `json:"country"`
+	Phone        string   `json:"phone"`
+	LDAPEnabled  bool     `json:"ldapEnabled"`
+	RatingPlanID string   `json:"ratingPlanId,omitempty"`
+}
+
+func userToInternal(u User) userInternal {
+	return userInternal{
+		GroupUserID:  u.GroupUserID,
+		UserType:     u.UserType,
+		CanonicalID:  u.CanonicalID,
+		Active:       strconv.FormatBool(u.Active),
+		FullName:     u.FullName,
+		EmailAddr:    u.EmailAddr,
+		Address1:     u.Address1,
+		Address2:     u.Address2,
+		City:         u.City,
+		State:        u.State,
+		Zip:          u.Zip,
+		Country:      u.Country,
+		Phone:        u.Phone,
+		LDAPEnabled:  u.LDAPEnabled,
+		RatingPlanID: u.RatingPlanID,
+	}
+}
+
+func userFromInternal(u userInternal) User {
+	return User{
+		GroupUserID:  u.GroupUserID,
+		UserType:     u.UserType,
+		CanonicalID:  u.CanonicalID,
+		Active:       u.Active == "true",
+		FullName:     u.FullName,
+		EmailAddr:    u.EmailAddr,
+		Address1:     u.Address1,
+		Address2:     u.Address2,
+		City:         u.City,
+		State:        u.State,
+		Zip:          u.Zip,
+		Country:      u.Country,
+		Phone:        u.Phone,
+		LDAPEnabled:  u.LDAPEnabled,
+		RatingPlanID: u.RatingPlanID,
+	}
 }
 
 // SecurityInfo is the Cloudian API's term for secure credentials
 type SecurityInfo struct {
+	AccessKey  string    `json:"accessKey"`
+	SecretKey  string    `json:"secretKey"`
+	Active     bool      `json:"active"`
+	CreateDate time.Time `json:"createDate"`
+}
+
+// securityInfoInternal is the SDK's internal representation of credentials.
+type securityInfoInternal struct {
 	AccessKey string `json:"accessKey"`
 	SecretKey string `json:"secretKey"`
+	Active    bool   `json:"active"`
+	// CreateDate is in milliseconds since the epoch.
+	CreateDate int64 `json:"createDate,omitempty"`
+}
+
+func securityInfoFromInternal(s securityInfoInternal) SecurityInfo {
+	var createDate time.Time
+	if s.CreateDate != 0 {
+		createDate = time.UnixMilli(s.CreateDate).UTC()
+	}
+	return SecurityInfo{
+		AccessKey:  s.AccessKey,
+		SecretKey:  s.SecretKey,
+		Active:     s.Active,
+		CreateDate: createDate,
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to the Cloudian API.
+func WithTLSConfig(config *tls.Config) func(*Client) {
+	return func(c *Client) {
+		c.client.SetTLSClientConfig(config)
+	}
+}
+
+// WithS3TLSConfig sets the TLS configuration used to connect to the S3 service.
+// It does not affect requests to the Cloudian API.
+func WithS3TLSConfig(config *tls.Config) func(*Client) {
+	return func(c *Client) {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = config
+		c.s3HTTPClient = &http.Client{Transport: transport}
+	}
+}
+
+// WithTimeout limits how long each attempt of a request may take.
+func WithTimeout(timeout time.Duration) func(*Client) {
+	return func(c *Client) {
+		c.client.SetTimeout(timeout)
+	}
+}
+
+// WithRetries retries GET, HEAD and DELETE requests up to `count` times after
+// a connection error, 429 or 5xx response. The wait between attempts starts at
+// `waitTime` and grows exponentially, with jitter, up to `maxWaitTime`. Other
+// requests are not retried, as Cloudian creates resources with PUT and POST.
+func WithRetries(count int, waitTime, maxWaitTime time.Duration) func(*Client) {
+	return func(c *Client) {
+		c.client.
+			SetRetryCount(count).
+			SetRetryWaitTime(waitTime).
+			SetRetryMaxWaitTime(maxWaitTime).
+			AddRetryCondition(func(resp *resty.Response, err error) bool {
+				if resp == nil || !idempotent(resp.Request.Method) || errors.Is(err, ErrCircuitOpen) {
+					return false
+				}
+				if err != nil {
+					var netErr net.Error
+					return errors.As(err, &netErr)
+				}
+				code := resp.StatusCode()
+				return code == http.StatusTooManyRequests || code >= 500 && code != http.StatusNotImplemented
+			})
+	}
 }
 
-var ErrNotFound = errors.New("not found")
+func idempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodDelete
+}
 
-// WithInsecureTLSVerify skips the TLS validation of the server certificate when `insecure` is true.
-func WithInsecureTLSVerify(insecure bool) func(*Client) {
+// WithCircuitBreaker fails requests fast with ErrCircuitOpen for
+// `openDuration` after `failureThreshold` consecutive requests failed with a
+// connection error or 5xx response. A single request is then let through to
+// probe whether the Cloudian API has recovered.
+func WithCircuitBreaker(failureThreshold int, openDuration time.Duration) func(*Client) {
 	return func(c *Client) {
-		c.client.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: insecure}) //nolint:gosec
+		newCircuitBreaker(failureThreshold, openDuration).install(c.client)
 	}
 }
 
+// NewTLSConfig builds a TLS configuration that trusts the PEM encoded certificates of `caBundle`,
+// or the system root CAs when it is empty. `serverName` overrides the host name used for verification.
+func NewTLSConfig(caBundle []byte, serverName string, insecure bool) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         serverName,
+		InsecureSkipVerify: insecure, //nolint:gosec
+	}
+
+	if len(caBundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, errors.New("no valid PEM encoded certificates found in CA bundle")
+		}
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
+
 func NewClient(baseURL string, authHeader string, opts ...func(*Client)) *Client {
 	c := &Client{
 		client: resty.New().
 			SetBaseURL(baseURL).
 			SetHeader("Authorization", authHeader),
+		s3HTTPClient: &http.Client{},
 	}
+	instrument(c.client)
 	for _, opt := range opts {
 		opt(c)
 	}
 	return c
 }
 
-// List all users of a group.
-func (client Client) ListUsers(ctx context.Context, groupID string, userID *string) ([]User, error) {
-	params := map[string]string{
-		"groupId":    groupID,
-		"userType":   "all",
-		"userStatus": "all",
-		"limit":      strconv.Itoa(ListLimit),
-	}
-	if userID != nil {
-		params["offset"] = *userID
-	}
-
-	var users []User
-	_, err := client.newRequest(ctx).
-		SetQueryParams(params).
-		SetResult(&users).
-		Get("/user/list")
-	if err != nil {
-		return nil, fmt.Errorf("GET list users failed: %w", err)
-	}
-
-	// Paginated API endpoint where limit+1 elements indicates more pages
-	if len(users) > ListLimit {
-		// Fetch remaining users starting from the user after the limit
-		moreUsers, err := client.ListUsers(ctx, groupID, &users[ListLimit].GroupUserID.UserID)
-		if err != nil {
-			return nil, err
-		}
-		// Exclude first user as it's a duplicate from previous fetch
-		users = append(users, moreUsers[1:]...)
-	}
+// HTTPClient returns the HTTP client of requests to the Cloudian API.
+func (client Client) HTTPClient() *http.Client {
+	return client.client.GetClient()
+}
 
-	return users, nil
+// S3HTTPClient returns the HTTP client of the S3 service of the Cloudian
+// cluster, which is shared by all S3 clients of the Cloudian client.
+func (client Client) S3HTTPClient() *http.Client {
+	return client.s3HTTPClient
 }
 
 // Delete a single user. Errors if the user does not exist.
@@ -184,7 +347,7 @@ func (client Client) DeleteUser(ctx context.Context, guid GroupUserID) error {
 	case 200:
 		return nil
 	default:
-		return fmt.Errorf("DELETE user unexpected status: %d", resp.StatusCode())
+		return newAPIError("DELETE user", resp)
 	}
 
 }
@@ -192,7 +355,7 @@ func (client Client) DeleteUser(ctx context.Context, guid GroupUserID) error {
 // Create a single user of type `User` into a groupId
 func (client Client) CreateUser(ctx context.Context, user User) error {
 	resp, err := client.newRequest(ctx).
-		SetBody(user).
+		SetBody(userToInternal(user)).
 		Put("/user")
 	if err != nil {
 		return err
@@ -202,14 +365,56 @@ func (client Client) CreateUser(ctx context.Context, user User) error {
 	case 200:
 		return nil
 	default:
-		return fmt.Errorf("CREATE user unexpected status: %d", resp.StatusCode())
+		return newAPIError("CREATE user", resp)
+	}
+}
+
+// SetUserPassword sets the password a user logs into the CMC with. The
+// password is sent in a form body, as the URL of a request may be logged.
+func (client Client) SetUserPassword(ctx context.Context, guid GroupUserID, password string) error {
+	resp, err := client.newRequest(ctx).
+		SetQueryParams(map[string]string{
+			"groupId": guid.GroupID,
+			"userId":  guid.UserID,
+		}).
+		SetFormData(map[string]string{"password": password}).
+		Post("/user/password")
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return nil
+	case 204:
+		// Cloudian-API returns 204 if the user does not exist
+		return ErrNotFound
+	default:
+		return newAPIError("SET user password", resp)
+	}
+}
+
+// UpdateUser updates the profile of an existing user.
+func (client Client) UpdateUser(ctx context.Context, user User) error {
+	resp, err := client.newRequest(ctx).
+		SetBody(userToInternal(user)).
+		Post("/user")
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return nil
+	default:
+		return newAPIError("UPDATE user", resp)
 	}
 }
 
 // GetUser gets a user. Returns an error even in the case of a user not found.
 // This error can then be checked against ErrNotFound: errors.Is(err, ErrNotFound)
 func (client Client) GetUser(ctx context.Context, guid GroupUserID) (*User, error) {
-	var user User
+	var user userInternal
 
 	resp, err := client.newRequest(ctx).
 		SetQueryParams(map[string]string{
@@ -224,18 +429,19 @@ func (client Client) GetUser(ctx context.Context, guid GroupUserID) (*User, erro
 
 	switch resp.StatusCode() {
 	case 200:
-		return &user, nil
+		retVal := userFromInternal(user)
+		return &retVal, nil
 	case 204:
 		// Cloudian-API returns 204 if the user does not exist
 		return nil, ErrNotFound
 	default:
-		return nil, fmt.Errorf("error: GET user unexpected status code: %d", resp.StatusCode())
+		return nil, newAPIError("GET user", resp)
 	}
 }
 
 // CreateUserCredentials creates a new set of credentials for a user.
 func (client Client) CreateUserCredentials(ctx context.Context, guid GroupUserID) (*SecurityInfo, error) {
-	var securityInfo SecurityInfo
+	var securityInfo securityInfoInternal
 
 	resp, err := client.newRequest(ctx).
 		SetResult(&securityInfo).
@@ -247,15 +453,16 @@ func (client Client) CreateUserCredentials(ctx context.Context, guid GroupUserID
 
 	switch resp.StatusCode() {
 	case 200:
-		return &securityInfo, nil
+		retVal := securityInfoFromInternal(securityInfo)
+		return &retVal, nil
 	default:
-		return nil, fmt.Errorf("CREATE user credentials unexpected status: %d", resp.StatusCode())
+		return nil, newAPIError("CREATE user credentials", resp)
 	}
 }
 
 // GetUserCredentials fetches all the credentials of a user.
 func (client Client) GetUserCredentials(ctx context.Context, accessKey string) (*SecurityInfo, error) {
-	var securityInfo SecurityInfo
+	var securityInfo securityInfoInternal
 
 	resp, err := client.newRequest(ctx).
 		SetQueryParams(map[string]string{"accessKey": accessKey}).
@@ -267,22 +474,23 @@ func (client Client) GetUserCredentials(ctx context.Context, accessKey string) (
 
 	switch resp.StatusCode() {
 	case 200:
-		return &securityInfo, nil
+		retVal := securityInfoFromInternal(securityInfo)
+		return &retVal, nil
 	case 204:
 		// Cloudian-API returns 204 if no security credentials found
 		return nil, ErrNotFound
 	default:
-		return nil, fmt.Errorf("error: list credentials unexpected status code: %d", resp.StatusCode())
+		return nil, newAPIError("GET user credentials", resp)
 	}
 }
 
 // ListUserCredentials fetches all the credentials of a user.
 func (client Client) ListUserCredentials(ctx context.Context, guid GroupUserID) ([]SecurityInfo, error) {
-	var securityInfo []SecurityInfo
+	var securityInfoInternals []securityInfoInternal
 
 	resp, err := client.newRequest(ctx).
 		SetQueryParams(map[string]string{"groupId": guid.GroupID, "userId": guid.UserID}).
-		SetResult(&securityInfo).
+		SetResult(&securityInfoInternals).
 		Get("/user/credentials/list")
 	if err != nil {
 		return nil, err
@@ -290,12 +498,40 @@ func (client Client) ListUserCredentials(ctx context.Context, guid GroupUserID)
 
 	switch resp.StatusCode() {
 	case 200:
+		securityInfo := make([]SecurityInfo, 0, len(securityInfoInternals))
+		for _, s := range securityInfoInternals {
+			securityInfo = append(securityInfo, securityInfoFromInternal(s))
+		}
 		return securityInfo, nil
 	case 204:
 		// Cloudian-API returns 204 if no security credentials found
 		return nil, nil
 	default:
-		return nil, fmt.Errorf("error: list credentials unexpected status code: %d", resp.StatusCode())
+		return nil, newAPIError("LIST user credentials", resp)
+	}
+}
+
+// UpdateUserCredentialsStatus activates or deactivates a set of credentials.
+// Inactive credentials are kept, but cannot be used to authenticate.
+func (client Client) UpdateUserCredentialsStatus(ctx context.Context, accessKey string, active bool) error {
+	resp, err := client.newRequest(ctx).
+		SetQueryParams(map[string]string{
+			"accessKey": accessKey,
+			"isActive":  strconv.FormatBool(active),
+		}).
+		Post("/user/credentials/status")
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return nil
+	case 204:
+		// Cloudian-API returns 204 if no security credentials found
+		return ErrNotFound
+	default:
+		return newAPIError("UPDATE user credentials status", resp)
 	}
 }
 
@@ -312,26 +548,88 @@ func (client Client) DeleteUserCredentials(ctx context.Context, accessKey string
 	case 200:
 		return nil
 	default:
-		return fmt.Errorf("DELETE credentials unexpected status: %d", resp.StatusCode())
+		return newAPIError("DELETE user credentials", resp)
 	}
 }
 
-// Delete a group and all its members.
+// Delete a group and all its members. The credentials and QoS limits in every
+// region of each user are deleted before the user, and the QoS limits of the
+// group before the group.
 func (client Client) DeleteGroupRecursive(ctx context.Context, groupID string) error {
-	users, err := client.ListUsers(ctx, groupID, nil)
+	regions, err := client.regions(ctx)
 	if err != nil {
-		return fmt.Errorf("error listing users: %w", err)
+		return fmt.Errorf("error listing regions: %w", err)
 	}
 
-	for _, user := range users {
-		if err := client.DeleteUser(ctx, user.GroupUserID); err != nil {
-			return fmt.Errorf("error deleting user: %w", err)
+	// Pages start at the ID of a user not yet listed, so deleting listed users
+	// does not affect pagination.
+	for user, err := range client.ListUsers(ctx, groupID, ListUsersOptions{}) {
+		if err != nil {
+			return fmt.Errorf("error listing users: %w", err)
+		}
+		if err := client.deleteUserRecursive(ctx, user.GroupUserID, regions); err != nil {
+			return err
 		}
 	}
 
+	guid := GroupUserID{GroupID: groupID, UserID: "*"}
+	if err := client.deleteQOS(ctx, guid, regions); err != nil {
+		return fmt.Errorf("error deleting group QoS limits: %w", err)
+	}
 	return client.DeleteGroup(ctx, groupID)
 }
 
+func (client Client) deleteUserRecursive(ctx context.Context, guid GroupUserID, regions []string) error {
+	credentials, err := client.ListUserCredentials(ctx, guid)
+	if err != nil {
+		return fmt.Errorf("error listing user credentials: %w", err)
+	}
+	for _, c := range credentials {
+		if err := client.DeleteUserCredentials(ctx, c.AccessKey); err != nil && !errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("error deleting user credentials: %w", err)
+		}
+	}
+
+	if err := client.deleteQOS(ctx, guid, regions); err != nil {
+		return fmt.Errorf("error deleting user QoS limits: %w", err)
+	}
+
+	if err := client.DeleteUser(ctx, guid); err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("error deleting user: %w", err)
+	}
+	return nil
+}
+
+// deleteQOS deletes the QoS limits of a group or user in each of regions.
+func (client Client) deleteQOS(ctx context.Context, guid GroupUserID, regions []string) error {
+	for _, region := range regions {
+		if err := client.DeleteQOS(ctx, guid, region); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// regions returns the regions of the storage policies of the cluster, as the
+// admin API does not list regions. Every region has a default storage policy.
+// Returns only DefaultRegion if there are no storage policies.
+func (client Client) regions(ctx context.Context) ([]string, error) {
+	policies, err := client.ListStoragePolicies(ctx, DefaultRegion)
+	if err != nil {
+		return nil, err
+	}
+	var regions []string
+	for _, p := range policies {
+		if p.Region != DefaultRegion && !slices.Contains(regions, p.Region) {
+			regions = append(regions, p.Region)
+		}
+	}
+	if len(regions) == 0 {
+		return []string{DefaultRegion}, nil
+	}
+	return regions, nil
+}
+
 // Deletes a group if it is without members.
 func (client Client) DeleteGroup(ctx context.Context, groupID string) error {
 	resp, err := client.newRequest(ctx).
@@ -345,7 +643,7 @@ func (client Client) DeleteGroup(ctx context.Context, groupID string) error {
 	case 200:
 		return nil
 	default:
-		return fmt.Errorf("DELETE group unexpected statusCode: %d", resp.StatusCode())
+		return newAPIError("DELETE group", resp)
 	}
 }
 
@@ -362,7 +660,7 @@ func (client Client) CreateGroup(ctx context.Context, group Group) error {
 	case 200:
 		return err
 	default:
-		return fmt.Errorf("CREATE group unexpected status: %d", resp.StatusCode())
+		return newAPIError("CREATE group", resp)
 	}
 }
 
@@ -379,7 +677,7 @@ func (client Client) UpdateGroup(ctx context.Context, group Group) error {
 	case 200:
 		return err
 	default:
-		return fmt.Errorf("Update group unexpected status: %d", resp.StatusCode())
+		return newAPIError("UPDATE group", resp)
 	}
 }
 
@@ -403,7 +701,7 @@ func (client Client) GetGroup(ctx context.Context, groupID string) (*Group, erro
 		// Cloudian-API returns 204 if the group does not exist
 		return nil, ErrNotFound
 	default:
-		return nil, fmt.Errorf("error: GET group unexpected status code: %d", resp.StatusCode())
+		return nil, newAPIError("GET group", resp)
 	}
 }
 
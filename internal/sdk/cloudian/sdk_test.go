@@ -3,14 +3,22 @@ package cloudian
 import (
 	"context"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 func TestGenericError(t *testing.T) {
@@ -61,10 +69,23 @@ func TestGetGroupNotFound(t *testing.T) {
 	}
 }
 
+func securityInfoToInternal(s SecurityInfo) securityInfoInternal {
+	var createDate int64
+	if !s.CreateDate.IsZero() {
+		createDate = s.CreateDate.UnixMilli()
+	}
+	return securityInfoInternal{
+		AccessKey:  s.AccessKey,
+		SecretKey:  s.SecretKey,
+		Active:     s.Active,
+		CreateDate: createDate,
+	}
+}
+
 func TestCreateCredentials(t *testing.T) {
-	expected := SecurityInfo{AccessKey: "123", SecretKey: "abc"}
+	expected := SecurityInfo{AccessKey: "123", SecretKey: "abc", Active: true, CreateDate: time.UnixMilli(1736939400000).UTC()}
 	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
-		json.NewEncoder(w).Encode(expected)
+		json.NewEncoder(w).Encode(securityInfoToInternal(expected))
 	})
 	defer testServer.Close()
 
@@ -80,7 +101,7 @@ func TestCreateCredentials(t *testing.T) {
 func TestGetUserCredentials(t *testing.T) {
 	expected := SecurityInfo{AccessKey: "123", SecretKey: "abc"}
 	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
-		json.NewEncoder(w).Encode(expected)
+		json.NewEncoder(w).Encode(securityInfoToInternal(expected))
 	})
 	defer testServer.Close()
 
@@ -95,11 +116,15 @@ func TestGetUserCredentials(t *testing.T) {
 
 func TestListUserCredentials(t *testing.T) {
 	expected := []SecurityInfo{
-		{AccessKey: "123", SecretKey: "abc"},
+		{AccessKey: "123", SecretKey: "abc", Active: true, CreateDate: time.UnixMilli(1736939400000).UTC()},
 		{AccessKey: "456", SecretKey: "def"},
 	}
 	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
-		json.NewEncoder(w).Encode(expected)
+		var page []securityInfoInternal
+		for _, s := range expected {
+			page = append(page, securityInfoToInternal(s))
+		}
+		json.NewEncoder(w).Encode(page)
 	})
 	defer testServer.Close()
 
@@ -114,6 +139,37 @@ func TestListUserCredentials(t *testing.T) {
 	}
 }
 
+func TestUpdateUserCredentialsStatus(t *testing.T) {
+	var query url.Values
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("UpdateUserCredentialsStatus() method = %s, want POST", r.Method)
+		}
+		query = r.URL.Query()
+	})
+	defer testServer.Close()
+
+	if err := cloudianClient.UpdateUserCredentialsStatus(context.TODO(), "123", false); err != nil {
+		t.Errorf("Error updating credentials status: %v", err)
+	}
+	want := url.Values{"accessKey": {"123"}, "isActive": {"false"}}
+	if diff := cmp.Diff(want, query); diff != "" {
+		t.Errorf("UpdateUserCredentialsStatus() query mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUpdateUserCredentialsStatusNotFound(t *testing.T) {
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer testServer.Close()
+
+	err := cloudianClient.UpdateUserCredentialsStatus(context.TODO(), "123", true)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected error to be ErrNotFound")
+	}
+}
+
 func TestListUsers(t *testing.T) {
 	var expected []User
 	for i := 0; i < 500; i++ {
@@ -136,13 +192,20 @@ func TestListUsers(t *testing.T) {
 		if end > len(expected) {
 			end = len(expected)
 		}
-		json.NewEncoder(w).Encode(expected[index:end])
+		var page []userInternal
+		for _, user := range expected[index:end] {
+			page = append(page, userToInternal(user))
+		}
+		json.NewEncoder(w).Encode(page)
 	})
 	defer testServer.Close()
 
-	users, err := cloudianClient.ListUsers(context.Background(), "QA", nil)
-	if err != nil {
-		t.Errorf("Error listing users: %v", err)
+	var users []User
+	for user, err := range cloudianClient.ListUsers(context.Background(), "QA", ListUsersOptions{}) {
+		if err != nil {
+			t.Fatalf("Error listing users: %v", err)
+		}
+		users = append(users, user)
 	}
 	if diff := cmp.Diff(expected, users); diff != "" {
 		t.Errorf("ListUsers() mismatch without offset (-want +got):\n%s", diff)
@@ -150,6 +213,169 @@ func TestListUsers(t *testing.T) {
 
 }
 
+// pageBy serves the items whose ID has the prefix query parameter, a page at a
+// time, like the list endpoints of Cloudian.
+func pageBy[T any](t *testing.T, items []T, id func(T) string, pages *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*pages++
+		query := r.URL.Query()
+		limit, err := strconv.Atoi(query.Get("limit"))
+		if err != nil {
+			t.Errorf("limit = %q, want a number", query.Get("limit"))
+		}
+
+		var matching []T
+		for _, item := range items {
+			if strings.HasPrefix(id(item), query.Get("prefix")) {
+				matching = append(matching, item)
+			}
+		}
+		index := sort.Search(len(matching), func(i int) bool { return id(matching[i]) >= query.Get("offset") })
+		// return one more than limit to indicate more pages
+		end := min(index+limit+1, len(matching))
+		json.NewEncoder(w).Encode(matching[index:end])
+	}
+}
+
+func TestListUsersPages(t *testing.T) {
+	var users []userInternal
+	for i := range 5000 {
+		users = append(users, userToInternal(User{GroupUserID: GroupUserID{GroupID: "QA", UserID: fmt.Sprintf("user%05d", i)}}))
+	}
+
+	cases := map[string]struct {
+		opts  ListUsersOptions
+		want  int
+		pages int
+	}{
+		"DefaultPageSize": {
+			want:  5000,
+			pages: 50,
+		},
+		"PageSize": {
+			opts:  ListUsersOptions{PageSize: 1000},
+			want:  5000,
+			pages: 5,
+		},
+		"Prefix": {
+			opts:  ListUsersOptions{Prefix: "user01", PageSize: 300},
+			want:  1000,
+			pages: 4,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pages := 0
+			handler := pageBy(t, users, func(u userInternal) string { return u.UserID }, &pages)
+			cloudianClient, testServer := mockBy(handler)
+			defer testServer.Close()
+
+			var got []string
+			for user, err := range cloudianClient.ListUsers(context.TODO(), "QA", tc.opts) {
+				if err != nil {
+					t.Fatalf("ListUsers() error = %v", err)
+				}
+				got = append(got, user.UserID)
+			}
+			if len(got) != tc.want || !sort.StringsAreSorted(got) || len(got) != len(slices.Compact(slices.Clone(got))) {
+				t.Errorf("ListUsers() = %d users, want %d unique users in order", len(got), tc.want)
+			}
+			if pages != tc.pages {
+				t.Errorf("ListUsers() requested %d pages, want %d", pages, tc.pages)
+			}
+		})
+	}
+}
+
+func TestListUsersFilters(t *testing.T) {
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("userType"); got != "GroupAdmin" {
+			t.Errorf("userType = %q, want %q", got, "GroupAdmin")
+		}
+		if got := query.Get("userStatus"); got != "inactive" {
+			t.Errorf("userStatus = %q, want %q", got, "inactive")
+		}
+		json.NewEncoder(w).Encode([]userInternal{})
+	})
+	defer testServer.Close()
+
+	opts := ListUsersOptions{UserType: UserTypeGroupAdmin, UserStatus: UserStatusInactive}
+	for _, err := range cloudianClient.ListUsers(context.TODO(), "QA", opts) {
+		t.Errorf("ListUsers() error = %v, want no users", err)
+	}
+}
+
+func TestListUsersStop(t *testing.T) {
+	var users []userInternal
+	for i := range 1000 {
+		users = append(users, userToInternal(User{GroupUserID: GroupUserID{GroupID: "QA", UserID: fmt.Sprintf("user%05d", i)}}))
+	}
+	pages := 0
+	cloudianClient, testServer := mockBy(pageBy(t, users, func(u userInternal) string { return u.UserID }, &pages))
+	defer testServer.Close()
+
+	listed := 0
+	for range cloudianClient.ListUsers(context.TODO(), "QA", ListUsersOptions{}) {
+		listed++
+		if listed == 150 {
+			break
+		}
+	}
+	if pages != 2 {
+		t.Errorf("ListUsers() requested %d pages, want 2 before stopping", pages)
+	}
+}
+
+func TestListUsersError(t *testing.T) {
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	defer testServer.Close()
+
+	errs := 0
+	for _, err := range cloudianClient.ListUsers(context.TODO(), "QA", ListUsersOptions{}) {
+		errs++
+		if statusCode(err) != http.StatusInternalServerError {
+			t.Errorf("ListUsers() error = %v, want 500", err)
+		}
+	}
+	if errs != 1 {
+		t.Errorf("ListUsers() yielded %d errors, want 1", errs)
+	}
+}
+
+func TestListGroups(t *testing.T) {
+	var groups []groupInternal
+	for i := range 3000 {
+		groups = append(groups, toInternal(Group{GroupID: fmt.Sprintf("group%05d", i), Active: true}))
+	}
+	pages := 0
+	handler := pageBy(t, groups, func(g groupInternal) string { return g.GroupID }, &pages)
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/group/list" {
+			t.Errorf("path = %q, want /group/list", r.URL.Path)
+		}
+		handler(w, r)
+	})
+	defer testServer.Close()
+
+	var got []Group
+	for group, err := range cloudianClient.ListGroups(context.TODO(), ListGroupsOptions{PageSize: 500}) {
+		if err != nil {
+			t.Fatalf("ListGroups() error = %v", err)
+		}
+		got = append(got, group)
+	}
+	if len(got) != 3000 || got[0].GroupID != "group00000" || got[2999].GroupID != "group02999" || !got[1234].Active {
+		t.Errorf("ListGroups() = %d groups, want all 3000 in order", len(got))
+	}
+	if pages != 6 {
+		t.Errorf("ListGroups() requested %d pages, want 6", pages)
+	}
+}
+
 func mockBy(handler http.HandlerFunc) (*Client, *httptest.Server) {
 	mockServer := httptest.NewServer(handler)
 	return NewClient(mockServer.URL, ""), mockServer
@@ -172,7 +398,7 @@ func TestClient_GetUser(t *testing.T) {
 		if statusCode == http.StatusOK {
 			for _, tt := range tests {
 				if tt.user.GroupUserID.UserID == userId {
-					json.NewEncoder(w).Encode(tt.user)
+					json.NewEncoder(w).Encode(userToInternal(tt.user))
 					break
 				}
 			}
@@ -202,3 +428,750 @@ func TestClient_GetUser(t *testing.T) {
 		})
 	}
 }
+
+func TestNewTLSConfig(t *testing.T) {
+	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(groupInternal{GroupID: "QA"})
+	}))
+	defer testServer.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: testServer.Certificate().Raw})
+
+	tests := []struct {
+		name       string
+		caBundle   []byte
+		serverName string
+		insecure   bool
+		wantErr    bool
+	}{
+		{name: "Trusted CA bundle", caBundle: caBundle},
+		{name: "Trusted CA bundle with server name", caBundle: caBundle, serverName: "example.com"},
+		{name: "Wrong server name", caBundle: caBundle, serverName: "cloudian.invalid", wantErr: true},
+		{name: "System roots", wantErr: true},
+		{name: "Insecure", insecure: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tlsConfig, err := NewTLSConfig(tt.caBundle, tt.serverName, tt.insecure)
+			if err != nil {
+				t.Fatalf("NewTLSConfig() error = %v", err)
+			}
+
+			client := NewClient(testServer.URL, "", WithTLSConfig(tlsConfig))
+			_, err = client.GetGroup(context.Background(), "QA")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetGroup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewTLSConfigInvalidCABundle(t *testing.T) {
+	if _, err := NewTLSConfig([]byte("not a certificate"), "", false); err == nil {
+		t.Error("Expected error for CA bundle without certificates")
+	}
+}
+
+func TestCreateUserType(t *testing.T) {
+	expected := User{
+		GroupUserID: GroupUserID{GroupID: "0", UserID: "admin1"},
+		UserType:    UserTypeSystemAdmin,
+		Active:      true,
+	}
+	var created []byte
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			created, _ = io.ReadAll(r.Body)
+		case http.MethodGet:
+			w.Write(created)
+		}
+	})
+	defer testServer.Close()
+
+	if err := cloudianClient.CreateUser(context.Background(), expected); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+	if !strings.Contains(string(created), `"userType":"SystemAdmin"`) {
+		t.Errorf("CreateUser() body = %s, want userType SystemAdmin", created)
+	}
+	got, err := cloudianClient.GetUser(context.Background(), expected.GroupUserID)
+	if err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+	if diff := cmp.Diff(&expected, got); diff != "" {
+		t.Errorf("GetUser() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUpdateUser(t *testing.T) {
+	expected := User{
+		GroupUserID: GroupUserID{GroupID: "QA", UserID: "user1"},
+		UserType:    UserTypeStandard,
+		Active:      true,
+		FullName:    "Jane Doe",
+		EmailAddr:   "jane@example.com",
+		Country:     "NO",
+	}
+	var got userInternal
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("UpdateUser() method = %s, want POST", r.Method)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+	})
+	defer testServer.Close()
+
+	if err := cloudianClient.UpdateUser(context.Background(), expected); err != nil {
+		t.Errorf("Error updating user: %v", err)
+	}
+	if got.Active != "true" {
+		t.Errorf("UpdateUser() active = %q, want \"true\"", got.Active)
+	}
+	if diff := cmp.Diff(expected, userFromInternal(got)); diff != "" {
+		t.Errorf("UpdateUser() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestStoragePolicyInternal(t *testing.T) {
+	policies := []StoragePolicy{
+		{
+			PolicyID: "rep", PolicyName: "Replicated", Region: "region1",
+			Scheme: StorageSchemeReplication, Replicas: 3, DataCenters: []string{"DC1", "DC2"},
+			Compression: "NONE", Encryption: "NONE", ReadConsistency: "QUORUM", WriteConsistency: "ALL",
+		},
+		{
+			PolicyID: "ec", PolicyName: "Erasure coded", Region: "region1",
+			Scheme: StorageSchemeErasureCoding, ECDataFragments: 4, ECParityFragments: 2, DataCenters: []string{"DC1"},
+			Compression: "LZ4", Encryption: "SSE", ReadConsistency: "ONE", WriteConsistency: "QUORUM", Groups: []string{"QA"},
+		},
+	}
+
+	for _, p := range policies {
+		t.Run(p.PolicyID, func(t *testing.T) {
+			if diff := cmp.Diff(p, storagePolicyFromInternal(storagePolicyToInternal(p))); diff != "" {
+				t.Errorf("storagePolicyFromInternal() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetStoragePolicy(t *testing.T) {
+	expected := StoragePolicy{
+		PolicyID: "abc", PolicyName: "Replicated", Scheme: StorageSchemeReplication, Replicas: 3,
+		DataCenters: []string{"DC1"}, ReadConsistency: "QUORUM", WriteConsistency: "QUORUM", Status: "ACTIVE",
+	}
+	deleted := StoragePolicy{PolicyID: "def", Status: "DELETED"}
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]storagePolicyInternal{
+			storagePolicyToInternal(expected),
+			storagePolicyToInternal(deleted),
+		})
+	})
+	defer testServer.Close()
+
+	policy, err := cloudianClient.GetStoragePolicy(context.TODO(), "abc")
+	if err != nil {
+		t.Fatalf("Error getting storage policy: %v", err)
+	}
+	if diff := cmp.Diff(expected, *policy); diff != "" {
+		t.Errorf("GetStoragePolicy() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := cloudianClient.GetStoragePolicy(context.TODO(), "def"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected deleted policy to be ErrNotFound, got %v", err)
+	}
+}
+
+func TestCreateStoragePolicy(t *testing.T) {
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("CreateStoragePolicy() method = %s, want PUT", r.Method)
+		}
+		var policy storagePolicyInternal
+		json.NewDecoder(r.Body).Decode(&policy)
+		policy.PolicyID = "generated"
+		json.NewEncoder(w).Encode(policy)
+	})
+	defer testServer.Close()
+
+	id, err := cloudianClient.CreateStoragePolicy(context.TODO(), StoragePolicy{PolicyName: "Replicated"})
+	if err != nil {
+		t.Fatalf("Error creating storage policy: %v", err)
+	}
+	if id != "generated" {
+		t.Errorf("CreateStoragePolicy() = %q, want %q", id, "generated")
+	}
+}
+
+func TestRatingPlanInternal(t *testing.T) {
+	plan := RatingPlan{
+		ID:       "gold",
+		Name:     "Gold",
+		Currency: "NOK",
+		Storage:  []RatingTier{{UpTo: 1000, Price: "0.25"}, {Price: "0.2"}},
+		GetRequests: []RatingTier{
+			{Price: "0.004"},
+		},
+	}
+
+	internal := ratingPlanToInternal(plan)
+	want := []ruleClassInternal{
+		{RuleClassType: "SB", Rules: "1000,0.25:0,0.2"},
+		{RuleClassType: "HG", Rules: "0,0.004"},
+	}
+	if diff := cmp.Diff(want, internal.RuleClasses); diff != "" {
+		t.Errorf("ratingPlanToInternal() mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err := ratingPlanFromInternal(internal)
+	if err != nil {
+		t.Fatalf("ratingPlanFromInternal() error = %v", err)
+	}
+	if diff := cmp.Diff(plan, got); diff != "" {
+		t.Errorf("ratingPlanFromInternal() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := ratingPlanFromInternal(ratingPlanInternal{RuleClasses: []ruleClassInternal{{RuleClassType: "SB", Rules: "1000"}}}); err == nil {
+		t.Error("Expected error for rule without price")
+	}
+}
+
+func TestGetRatingPlanNotFound(t *testing.T) {
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer testServer.Close()
+
+	if _, err := cloudianClient.GetRatingPlan(context.TODO(), "gold"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected error to be ErrNotFound")
+	}
+}
+
+func TestGetUsage(t *testing.T) {
+	since := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	totals := map[string][]int64{
+		"HG": {10, 5},
+		"HP": {3},
+		"HD": {1},
+		"BI": {1024, 2048},
+		"BO": {4096},
+	}
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("groupId"); r.URL.Path != "/usage" && got != "QA" {
+			t.Errorf("%s groupId = %q, want %q", r.URL.Path, got, "QA")
+		}
+		switch r.URL.Path {
+		case "/system/bytecount":
+			if got := query.Get("userId"); got != "user1" {
+				t.Errorf("bytecount userId = %q, want %q", got, "user1")
+			}
+			json.NewEncoder(w).Encode(int64(5 << 30))
+		case "/system/objectcount":
+			json.NewEncoder(w).Encode(int64(1234))
+		case "/usage":
+			if got := query.Get("id"); got != "QA|user1" {
+				t.Errorf("usage id = %q, want %q", got, "QA|user1")
+			}
+			if got := query.Get("startTime"); got != "202503011200" {
+				t.Errorf("usage startTime = %q, want %q", got, "202503011200")
+			}
+			var records []usageDataInternal
+			for _, v := range totals[query.Get("operation")] {
+				records = append(records, usageDataInternal{Value: v})
+			}
+			json.NewEncoder(w).Encode(records)
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+	})
+	defer testServer.Close()
+
+	usage, err := cloudianClient.GetUsage(context.TODO(), GroupUserID{GroupID: "QA", UserID: "user1"}, since)
+	if err != nil {
+		t.Fatalf("Error getting usage: %v", err)
+	}
+	expected := Usage{StorageBytes: 5 << 30, ObjectCount: 1234, Requests: 19, BytesIn: 3072, BytesOut: 4096}
+	if diff := cmp.Diff(expected, *usage); diff != "" {
+		t.Errorf("GetUsage() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetGroupUsage(t *testing.T) {
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/system/bytecount", "/system/objectcount":
+			if query.Has("userId") {
+				t.Errorf("%s userId = %q, want unset", r.URL.Path, query.Get("userId"))
+			}
+			json.NewEncoder(w).Encode(int64(42))
+		case "/usage":
+			if got := query.Get("id"); got != "QA" {
+				t.Errorf("usage id = %q, want %q", got, "QA")
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	defer testServer.Close()
+
+	usage, err := cloudianClient.GetUsage(context.TODO(), GroupUserID{GroupID: "QA", UserID: "*"}, time.Now())
+	if err != nil {
+		t.Fatalf("Error getting usage: %v", err)
+	}
+	expected := Usage{StorageBytes: 42, ObjectCount: 42}
+	if diff := cmp.Diff(expected, *usage); diff != "" {
+		t.Errorf("GetUsage() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetUsageNotFound(t *testing.T) {
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer testServer.Close()
+
+	_, err := cloudianClient.GetUsage(context.TODO(), GroupUserID{GroupID: "QA", UserID: "*"}, time.Now())
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected error to be ErrNotFound, got %v", err)
+	}
+}
+
+func TestRequestMetrics(t *testing.T) {
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer testServer.Close()
+
+	counter := requestsTotal.WithLabelValues(http.MethodGet, "/group", "204")
+	before := testutil.ToFloat64(counter)
+
+	if _, err := cloudianClient.GetGroup(context.TODO(), "QA"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected error to be ErrNotFound, got %v", err)
+	}
+
+	if got := testutil.ToFloat64(counter) - before; got != 1 {
+		t.Errorf("cloudian_api_requests_total increased by %v, want 1", got)
+	}
+}
+
+func TestRequestMetricsError(t *testing.T) {
+	testServer := httptest.NewServer(http.NotFoundHandler())
+	cloudianClient := NewClient(testServer.URL, "")
+	testServer.Close()
+
+	counter := requestsTotal.WithLabelValues(http.MethodGet, "/group", "error")
+	before := testutil.ToFloat64(counter)
+
+	if _, err := cloudianClient.GetGroup(context.TODO(), "QA"); err == nil {
+		t.Fatal("Expected error from closed server")
+	}
+
+	if got := testutil.ToFloat64(counter) - before; got != 1 {
+		t.Errorf("cloudian_api_requests_total increased by %v, want 1", got)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, "Group already exists\n")
+	})
+	defer testServer.Close()
+
+	err := cloudianClient.CreateGroup(context.TODO(), Group{GroupID: "QA"})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected error to be an APIError, got %v", err)
+	}
+	expected := APIError{
+		Operation:  "CREATE group",
+		Method:     http.MethodPut,
+		Path:       "/group",
+		StatusCode: http.StatusConflict,
+		Message:    "Group already exists",
+	}
+	if diff := cmp.Diff(expected, *apiErr); diff != "" {
+		t.Errorf("CreateGroup() error mismatch (-want +got):\n%s", diff)
+	}
+	if want := "CREATE group unexpected status: 409 (PUT /group): Group already exists"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if !IsConflict(err) || IsUnauthorized(err) || IsRetryable(err) {
+		t.Errorf("Expected error to only be a conflict")
+	}
+}
+
+func TestAPIErrorJSONMessage(t *testing.T) {
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"message":"Service is starting"}`)
+	})
+	defer testServer.Close()
+
+	_, err := cloudianClient.GetGroup(context.TODO(), "QA")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "Service is starting" {
+		t.Errorf("Expected APIError with decoded message, got %v", err)
+	}
+	if !IsRetryable(err) {
+		t.Errorf("Expected 503 to be retryable")
+	}
+}
+
+func TestAPIErrorNotFound(t *testing.T) {
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer testServer.Close()
+
+	err := cloudianClient.DeleteUser(context.TODO(), GroupUserID{GroupID: "QA", UserID: "user1"})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected 404 to be ErrNotFound, got %v", err)
+	}
+}
+
+func TestErrorPredicates(t *testing.T) {
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	_, netErr := NewClient(closed.URL, "").GetGroup(context.TODO(), "QA")
+
+	cases := map[string]struct {
+		err          error
+		conflict     bool
+		unauthorized bool
+		forbidden    bool
+		retryable    bool
+	}{
+		"Conflict":        {err: &APIError{StatusCode: 409}, conflict: true},
+		"Unauthorized":    {err: &APIError{StatusCode: 401}, unauthorized: true},
+		"Forbidden":       {err: fmt.Errorf("wrapped: %w", &APIError{StatusCode: 403}), forbidden: true},
+		"TooManyRequests": {err: &APIError{StatusCode: 429}, retryable: true},
+		"BadGateway":      {err: &APIError{StatusCode: 502}, retryable: true},
+		"NotImplemented":  {err: &APIError{StatusCode: 501}},
+		"BadRequest":      {err: &APIError{StatusCode: 400}},
+		"Network":         {err: netErr, retryable: true},
+		"Other":           {err: errors.New("boom")},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsConflict(tc.err); got != tc.conflict {
+				t.Errorf("IsConflict() = %v, want %v", got, tc.conflict)
+			}
+			if got := IsUnauthorized(tc.err); got != tc.unauthorized {
+				t.Errorf("IsUnauthorized() = %v, want %v", got, tc.unauthorized)
+			}
+			if got := IsForbidden(tc.err); got != tc.forbidden {
+				t.Errorf("IsForbidden() = %v, want %v", got, tc.forbidden)
+			}
+			if got := IsRetryable(tc.err); got != tc.retryable {
+				t.Errorf("IsRetryable() = %v, want %v", got, tc.retryable)
+			}
+		})
+	}
+}
+
+func TestRetries(t *testing.T) {
+	attempts := map[string]int{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts[r.Method]++
+		if attempts[r.Method] <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(toInternal(Group{GroupID: "QA"}))
+	}))
+	defer testServer.Close()
+	cloudianClient := NewClient(testServer.URL, "", WithRetries(3, time.Millisecond, time.Millisecond))
+
+	if _, err := cloudianClient.GetGroup(context.TODO(), "QA"); err != nil {
+		t.Errorf("GetGroup() error = %v, want success after retries", err)
+	}
+	if attempts[http.MethodGet] != 3 {
+		t.Errorf("GET attempts = %d, want 3", attempts[http.MethodGet])
+	}
+
+	if err := cloudianClient.CreateGroup(context.TODO(), Group{GroupID: "QA"}); !IsRetryable(err) {
+		t.Errorf("CreateGroup() error = %v, want retryable error", err)
+	}
+	if attempts[http.MethodPut] != 1 {
+		t.Errorf("PUT attempts = %d, want 1", attempts[http.MethodPut])
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	requests := 0
+	healthy := false
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if !healthy {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		json.NewEncoder(w).Encode(toInternal(Group{GroupID: "QA"}))
+	}))
+	defer testServer.Close()
+
+	now := time.Now()
+	breaker := newCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+	cloudianClient := NewClient(testServer.URL, "", func(c *Client) { breaker.install(c.client) })
+
+	for range 2 {
+		if _, err := cloudianClient.GetGroup(context.TODO(), "QA"); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("GetGroup() error = %v before reaching the failure threshold", err)
+		}
+	}
+	if _, err := cloudianClient.GetGroup(context.TODO(), "QA"); !errors.Is(err, ErrCircuitOpen) || !IsRetryable(err) {
+		t.Errorf("GetGroup() error = %v, want ErrCircuitOpen", err)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2 while the circuit is open", requests)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := cloudianClient.GetGroup(context.TODO(), "QA"); errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("GetGroup() error = %v, want probe after cooldown", err)
+	}
+	if _, err := cloudianClient.GetGroup(context.TODO(), "QA"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("GetGroup() error = %v, want ErrCircuitOpen after failed probe", err)
+	}
+
+	healthy = true
+	now = now.Add(time.Minute)
+	for range 2 {
+		if _, err := cloudianClient.GetGroup(context.TODO(), "QA"); err != nil {
+			t.Errorf("GetGroup() error = %v, want closed circuit after successful probe", err)
+		}
+	}
+}
+
+func TestCircuitBreakerCanceledProbe(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer testServer.Close()
+
+	now := time.Now()
+	breaker := newCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+	cloudianClient := NewClient(testServer.URL, "", func(c *Client) { breaker.install(c.client) })
+
+	if _, err := cloudianClient.GetGroup(context.TODO(), "QA"); errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("GetGroup() error = %v before reaching the failure threshold", err)
+	}
+
+	now = now.Add(time.Minute)
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	if _, err := cloudianClient.GetGroup(ctx, "QA"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("GetGroup() error = %v, want canceled probe", err)
+	}
+	if _, err := cloudianClient.GetGroup(context.TODO(), "QA"); errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("GetGroup() error = %v, want a new probe after the canceled one", err)
+	}
+}
+
+func TestEndpointFailover(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(toInternal(Group{GroupID: "QA"}))
+	}))
+	defer up.Close()
+
+	cloudianClient := NewClient("", "",
+		WithRetries(1, time.Millisecond, time.Millisecond),
+		WithEndpoints([]string{down.URL, up.URL}, EndpointPolicyPriority))
+
+	if _, err := cloudianClient.GetGroup(context.TODO(), "QA"); err != nil {
+		t.Fatalf("GetGroup() error = %v, want retry on healthy endpoint", err)
+	}
+	if got := cloudianClient.ActiveEndpoint(); got != up.URL {
+		t.Errorf("ActiveEndpoint() = %q, want %q", got, up.URL)
+	}
+
+	statuses := cloudianClient.Endpoints()
+	if len(statuses) != 2 || statuses[0].Healthy || !statuses[1].Healthy {
+		t.Fatalf("Endpoints() = %+v, want first unhealthy and second healthy", statuses)
+	}
+	if statuses[0].LastError != "unexpected status: 503" {
+		t.Errorf("LastError = %q, want %q", statuses[0].LastError, "unexpected status: 503")
+	}
+}
+
+func TestEndpointRoundRobin(t *testing.T) {
+	var requests [2]int
+	newServer := func(i int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests[i]++
+			json.NewEncoder(w).Encode(toInternal(Group{GroupID: "QA"}))
+		}))
+	}
+	first, second := newServer(0), newServer(1)
+	defer first.Close()
+	defer second.Close()
+
+	cloudianClient := NewClient("", "", WithEndpoints([]string{first.URL, second.URL}, EndpointPolicyRoundRobin))
+
+	for range 4 {
+		if _, err := cloudianClient.GetGroup(context.TODO(), "QA"); err != nil {
+			t.Fatalf("GetGroup() error = %v", err)
+		}
+	}
+	if requests != [2]int{2, 2} {
+		t.Errorf("requests = %v, want evenly spread", requests)
+	}
+}
+
+func TestEndpointRecheck(t *testing.T) {
+	now := time.Now()
+	pool := newEndpointPool([]string{"https://a/", "https://b"}, EndpointPolicyPriority)
+	pool.now = func() time.Time { return now }
+
+	pool.record("https://a/group", nil, http.StatusBadGateway)
+	if got := pool.pick(); got != "https://b" {
+		t.Errorf("pick() = %q, want healthy endpoint", got)
+	}
+
+	pool.record("https://b/group", nil, http.StatusBadGateway)
+	if got := pool.pick(); got != "https://a" {
+		t.Errorf("pick() = %q, want endpoint that failed first when all are unhealthy", got)
+	}
+
+	now = now.Add(endpointRecheckInterval)
+	pool.record("https://b/group", nil, http.StatusOK)
+	if got := pool.pick(); got != "https://a" {
+		t.Errorf("pick() = %q, want unhealthy endpoint rechecked by priority", got)
+	}
+}
+
+func TestCheckEndpoints(t *testing.T) {
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/system/version" {
+			t.Errorf("path = %q, want /system/version", r.URL.Path)
+		}
+		w.Write([]byte("8.1.0"))
+	}))
+	defer up.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	down.Close()
+
+	cloudianClient := NewClient("", "", WithEndpoints([]string{up.URL, down.URL}, EndpointPolicyPriority))
+
+	statuses := cloudianClient.CheckEndpoints(context.TODO())
+	if len(statuses) != 2 || !statuses[0].Healthy || statuses[1].Healthy || statuses[1].LastError == "" {
+		t.Errorf("CheckEndpoints() = %+v, want first healthy and second unreachable", statuses)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	requests := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewEncoder(w).Encode(toInternal(Group{GroupID: "QA"}))
+	}))
+	defer testServer.Close()
+
+	cloudianClient := NewClient(testServer.URL, "", WithRateLimit(1, 1))
+
+	if _, err := cloudianClient.GetGroup(context.TODO(), "QA"); err != nil {
+		t.Fatalf("GetGroup() error = %v, want burst to be allowed", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := cloudianClient.GetGroup(ctx, "QA"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("GetGroup() error = %v, want ErrRateLimited", err)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1 while rate limited", requests)
+	}
+}
+
+func TestRateLimitCircuitBreakerProbe(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer testServer.Close()
+
+	now := time.Now()
+	breaker := newCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+	cloudianClient := NewClient(testServer.URL, "", func(c *Client) { breaker.install(c.client) }, WithRateLimit(20, 1))
+
+	if _, err := cloudianClient.GetGroup(context.TODO(), "QA"); errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("GetGroup() error = %v before reaching the failure threshold", err)
+	}
+
+	// The probe is chosen by the circuit breaker before it waits for the
+	// rate limiter, which it cannot do within its deadline.
+	now = now.Add(time.Minute)
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Millisecond)
+	defer cancel()
+	if _, err := cloudianClient.GetGroup(ctx, "QA"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("GetGroup() error = %v, want rate limited probe", err)
+	}
+	if _, err := cloudianClient.GetGroup(context.TODO(), "QA"); errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("GetGroup() error = %v, want a new probe after the rate limited one", err)
+	}
+}
+
+func TestPurgeUser(t *testing.T) {
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/user/purge" {
+			t.Errorf("request = %s %s, want POST /user/purge", r.Method, r.URL.Path)
+		}
+		if r.URL.Query().Get("userId") == "gone" {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	defer testServer.Close()
+
+	if err := cloudianClient.PurgeUser(context.TODO(), GroupUserID{GroupID: "QA", UserID: "user1"}); err != nil {
+		t.Errorf("PurgeUser() error = %v", err)
+	}
+	if err := cloudianClient.PurgeUser(context.TODO(), GroupUserID{GroupID: "QA", UserID: "gone"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("PurgeUser() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSetUserPassword(t *testing.T) {
+	cloudianClient, testServer := mockBy(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.Method != http.MethodPost || r.URL.Path != "/user/password" {
+			t.Errorf("request = %s %s, want POST /user/password", r.Method, r.URL.Path)
+		}
+		if query.Get("groupId") != "QA" || query.Get("userId") != "user1" || query.Has("password") {
+			t.Errorf("query = %v, want groupId and userId without password", query)
+		}
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("password") != "s3cret!" {
+			t.Errorf("form = %v, want password", r.PostForm)
+		}
+	})
+	defer testServer.Close()
+
+	if err := cloudianClient.SetUserPassword(context.TODO(), GroupUserID{GroupID: "QA", UserID: "user1"}, "s3cret!"); err != nil {
+		t.Errorf("SetUserPassword() error = %v", err)
+	}
+}
+
+func TestSetUserPasswordErrorRedacted(t *testing.T) {
+	cloudianClient, testServer := mockBy(func(http.ResponseWriter, *http.Request) {})
+	testServer.Close()
+
+	err := cloudianClient.SetUserPassword(context.TODO(), GroupUserID{GroupID: "QA", UserID: "user1"}, "s3cret!")
+	if err == nil {
+		t.Fatal("SetUserPassword() expected error of closed server")
+	}
+	if strings.Contains(err.Error(), "s3cret") {
+		t.Errorf("SetUserPassword() error = %q, leaks the password", err)
+	}
+}
@@ -0,0 +1,248 @@
+package cloudian
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// StorageScheme is how a storage policy protects data in each data center.
+type StorageScheme string
+
+const (
+	StorageSchemeReplication   StorageScheme = "REPLICATION"
+	StorageSchemeErasureCoding StorageScheme = "EC"
+)
+
+// StoragePolicy is a bucket protection policy, which defines how the data of
+// the buckets bound to it is stored.
+type StoragePolicy struct {
+	// PolicyID is assigned by Cloudian when the policy is created.
+	PolicyID    string
+	PolicyName  string
+	Description string
+	Region      string
+	Scheme      StorageScheme
+	// Replicas is the number of replicas per data center of a replication
+	// policy.
+	Replicas int
+	// ECDataFragments (k) and ECParityFragments (m) split each object of an
+	// erasure coding policy into k data and m parity fragments.
+	ECDataFragments   int
+	ECParityFragments int
+	DataCenters       []string
+	// Compression is NONE, SNAPPY, ZLIB or LZ4.
+	Compression string
+	// Encryption is NONE, or SSE to encrypt objects at rest.
+	Encryption string
+	// ReadConsistency and WriteConsistency are ONE, QUORUM, LOCAL_QUORUM,
+	// EACH_QUORUM or ALL.
+	ReadConsistency  string
+	WriteConsistency string
+	// Groups may bind buckets to the policy. All groups may if it is empty.
+	Groups []string
+	// Default is whether buckets are bound to the policy unless they specify
+	// another one.
+	Default bool
+	// Status is ACTIVE, PENDING or DISABLED.
+	Status string
+}
+
+// storagePolicyInternal is the SDK's internal representation of a Cloudian
+// storage policy.
+type storagePolicyInternal struct {
+	PolicyID          string `json:"policyId,omitempty"`
+	PolicyName        string `json:"policyName"`
+	PolicyDescription string `json:"policyDescription"`
+	Region            string `json:"region"`
+	// ReplicationScheme maps data centers to their number of replicas, like
+	// "3", or to their erasure coding fragments, like "4+2".
+	ReplicationScheme map[string]string          `json:"replicationScheme"`
+	ConsistencyLevels storagePolicyConsistencies `json:"consistencyLevels"`
+	Compression       string                     `json:"compression"`
+	Encryption        string                     `json:"encryption"`
+	Groups            []string                   `json:"groups"`
+	Default           bool                       `json:"default"`
+	Status            string                     `json:"status,omitempty"`
+}
+
+type storagePolicyConsistencies struct {
+	Read  []string `json:"readCL"`
+	Write []string `json:"writeCL"`
+}
+
+func storagePolicyToInternal(p StoragePolicy) storagePolicyInternal {
+	scheme := strconv.Itoa(p.Replicas)
+	if p.Scheme == StorageSchemeErasureCoding {
+		scheme = fmt.Sprintf("%d+%d", p.ECDataFragments, p.ECParityFragments)
+	}
+	replication := make(map[string]string, len(p.DataCenters))
+	for _, dc := range p.DataCenters {
+		replication[dc] = scheme
+	}
+
+	return storagePolicyInternal{
+		PolicyID:          p.PolicyID,
+		PolicyName:        p.PolicyName,
+		PolicyDescription: p.Description,
+		Region:            p.Region,
+		ReplicationScheme: replication,
+		ConsistencyLevels: storagePolicyConsistencies{
+			Read:  []string{p.ReadConsistency},
+			Write: []string{p.WriteConsistency},
+		},
+		Compression: p.Compression,
+		Encryption:  p.Encryption,
+		Groups:      p.Groups,
+		Default:     p.Default,
+		Status:      p.Status,
+	}
+}
+
+func storagePolicyFromInternal(p storagePolicyInternal) StoragePolicy {
+	policy := StoragePolicy{
+		PolicyID:    p.PolicyID,
+		PolicyName:  p.PolicyName,
+		Description: p.PolicyDescription,
+		Region:      p.Region,
+		Compression: p.Compression,
+		Encryption:  p.Encryption,
+		Groups:      p.Groups,
+		Default:     p.Default,
+		Status:      p.Status,
+	}
+	if len(p.ConsistencyLevels.Read) > 0 {
+		policy.ReadConsistency = p.ConsistencyLevels.Read[0]
+	}
+	if len(p.ConsistencyLevels.Write) > 0 {
+		policy.WriteConsistency = p.ConsistencyLevels.Write[0]
+	}
+
+	for dc, scheme := range p.ReplicationScheme {
+		policy.DataCenters = append(policy.DataCenters, dc)
+		if k, m, ok := strings.Cut(scheme, "+"); ok {
+			policy.Scheme = StorageSchemeErasureCoding
+			policy.ECDataFragments, _ = strconv.Atoi(k)
+			policy.ECParityFragments, _ = strconv.Atoi(m)
+		} else {
+			policy.Scheme = StorageSchemeReplication
+			policy.Replicas, _ = strconv.Atoi(scheme)
+		}
+	}
+	slices.Sort(policy.DataCenters)
+
+	return policy
+}
+
+// ListStoragePolicies lists the storage policies of a region, or of all
+// regions if region is DefaultRegion. Deleted policies are not listed.
+func (client Client) ListStoragePolicies(ctx context.Context, region string) ([]StoragePolicy, error) {
+	params := make(map[string]string)
+	if region != DefaultRegion {
+		params["region"] = region
+	}
+
+	var policies []storagePolicyInternal
+	resp, err := client.newRequest(ctx).
+		SetQueryParams(params).
+		SetResult(&policies).
+		Get("/bppolicy/listpolicy")
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		retVal := make([]StoragePolicy, 0, len(policies))
+		for _, p := range policies {
+			if p.Status == "DELETED" {
+				continue
+			}
+			retVal = append(retVal, storagePolicyFromInternal(p))
+		}
+		return retVal, nil
+	case 204:
+		// Cloudian-API returns 204 if there are no storage policies
+		return nil, nil
+	default:
+		return nil, newAPIError("LIST storage policies", resp)
+	}
+}
+
+// GetStoragePolicy gets a storage policy. Returns ErrNotFound if the policy
+// does not exist, or is deleted.
+func (client Client) GetStoragePolicy(ctx context.Context, policyID string) (*StoragePolicy, error) {
+	policies, err := client.ListStoragePolicies(ctx, DefaultRegion)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range policies {
+		if p.PolicyID == policyID {
+			return &p, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// CreateStoragePolicy creates a storage policy, and returns the ID Cloudian
+// assigned to it.
+func (client Client) CreateStoragePolicy(ctx context.Context, policy StoragePolicy) (string, error) {
+	policy.PolicyID = ""
+	var created storagePolicyInternal
+	resp, err := client.newRequest(ctx).
+		SetBody(storagePolicyToInternal(policy)).
+		SetResult(&created).
+		Put("/bppolicy")
+	if err != nil {
+		return "", err
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return created.PolicyID, nil
+	default:
+		return "", newAPIError("CREATE storage policy", resp)
+	}
+}
+
+// UpdateStoragePolicy updates the name, description, compression, encryption,
+// consistency levels and groups of a storage policy. The data protection
+// scheme of a policy cannot be changed.
+func (client Client) UpdateStoragePolicy(ctx context.Context, policy StoragePolicy) error {
+	resp, err := client.newRequest(ctx).
+		SetBody(storagePolicyToInternal(policy)).
+		Post("/bppolicy")
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return nil
+	case 204:
+		return ErrNotFound
+	default:
+		return newAPIError("UPDATE storage policy", resp)
+	}
+}
+
+// DeleteStoragePolicy deletes a storage policy that no bucket is bound to.
+func (client Client) DeleteStoragePolicy(ctx context.Context, policyID string) error {
+	resp, err := client.newRequest(ctx).
+		SetQueryParam("policyId", policyID).
+		Delete("/bppolicy")
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return nil
+	case 204:
+		return ErrNotFound
+	default:
+		return newAPIError("DELETE storage policy", resp)
+	}
+}
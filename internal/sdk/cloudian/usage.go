@@ -0,0 +1,138 @@
+package cloudian
+
+import (
+	"context"
+	"time"
+)
+
+// Usage is the consumption of a group or user. Stored bytes and objects are
+// current, while requests and transfers are totals since a point in time.
+type Usage struct {
+	StorageBytes int64
+	ObjectCount  int64
+	// Requests is the number of GET, PUT and DELETE requests.
+	Requests int64
+	BytesIn  int64
+	BytesOut int64
+}
+
+// Usage operations of the Cloudian usage API.
+const (
+	usageOperationGetRequests    = "HG"
+	usageOperationPutRequests    = "HP"
+	usageOperationDeleteRequests = "HD"
+	usageOperationBytesIn        = "BI"
+	usageOperationBytesOut       = "BO"
+)
+
+// usageTimeLayout is the time format of the Cloudian usage API.
+const usageTimeLayout = "200601021504"
+
+// usageDataInternal is a usage record of the Cloudian usage API.
+type usageDataInternal struct {
+	Timestamp int64 `json:"timestamp"`
+	Value     int64 `json:"value"`
+}
+
+// GetUsage gets the usage of a group or user, with request and transfer
+// totals since a point in time. The usage of a whole group is returned if
+// UserID is "*".
+func (client Client) GetUsage(ctx context.Context, guid GroupUserID, since time.Time) (*Usage, error) {
+	usage, err := client.GetStoredUsage(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := map[string]*int64{
+		usageOperationGetRequests:    &usage.Requests,
+		usageOperationPutRequests:    &usage.Requests,
+		usageOperationDeleteRequests: &usage.Requests,
+		usageOperationBytesIn:        &usage.BytesIn,
+		usageOperationBytesOut:       &usage.BytesOut,
+	}
+	for operation, total := range totals {
+		n, err := client.getUsageTotal(ctx, guid, operation, since)
+		if err != nil {
+			return nil, err
+		}
+		*total += n
+	}
+
+	return usage, nil
+}
+
+// GetStoredUsage gets the stored bytes and objects of a group or user, which
+// is cheaper than GetUsage. The usage of a whole group is returned if UserID
+// is "*".
+func (client Client) GetStoredUsage(ctx context.Context, guid GroupUserID) (*Usage, error) {
+	var usage Usage
+	var err error
+
+	if usage.StorageBytes, err = client.getCount(ctx, guid, "/system/bytecount"); err != nil {
+		return nil, err
+	}
+	if usage.ObjectCount, err = client.getCount(ctx, guid, "/system/objectcount"); err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+// getCount gets the stored bytes or objects of a group or user.
+func (client Client) getCount(ctx context.Context, guid GroupUserID, path string) (int64, error) {
+	req := client.newRequest(ctx).SetQueryParam("groupId", guid.GroupID)
+	if guid.UserID != "*" {
+		req.SetQueryParam("userId", guid.UserID)
+	}
+
+	var count int64
+	resp, err := req.SetResult(&count).Get(path)
+	if err != nil {
+		return 0, err
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return count, nil
+	case 204:
+		// Cloudian-API returns 204 if the group or user does not exist
+		return 0, ErrNotFound
+	default:
+		return 0, newAPIError("GET "+path, resp)
+	}
+}
+
+// getUsageTotal sums the hourly usage records of an operation since a point
+// in time.
+func (client Client) getUsageTotal(ctx context.Context, guid GroupUserID, operation string, since time.Time) (int64, error) {
+	id := guid.GroupID
+	if guid.UserID != "*" {
+		id += "|" + guid.UserID
+	}
+
+	var records []usageDataInternal
+	resp, err := client.newRequest(ctx).
+		SetQueryParam("id", id).
+		SetQueryParam("operation", operation).
+		SetQueryParam("startTime", since.UTC().Format(usageTimeLayout)).
+		SetQueryParam("endTime", time.Now().UTC().Format(usageTimeLayout)).
+		SetQueryParam("granularity", "hour").
+		SetResult(&records).
+		Get("/usage")
+	if err != nil {
+		return 0, err
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		var total int64
+		for _, r := range records {
+			total += r.Value
+		}
+		return total, nil
+	case 204:
+		// Cloudian-API returns 204 if there is no usage in the period
+		return 0, nil
+	default:
+		return 0, newAPIError("GET usage", resp)
+	}
+}
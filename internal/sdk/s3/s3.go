@@ -0,0 +1,366 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package s3 is a client of the S3 API of Cloudian HyperStore.
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// DefaultRegion is used to sign requests when no region is configured.
+const DefaultRegion = "us-east-1"
+
+// headerStoragePolicyID selects the Cloudian storage policy of a new bucket.
+const headerStoragePolicyID = "x-gmt-policyid"
+
+var ErrNotFound = errors.New("not found")
+
+// Client performs S3 requests with the credentials of a single access key.
+type Client struct {
+	s3 *s3.Client
+}
+
+// Bucket is an S3 bucket.
+type Bucket struct {
+	Name string
+	// Region is the location constraint of the bucket.
+	Region string
+	// StoragePolicyID is the Cloudian storage policy of the bucket. It can
+	// only be set when the bucket is created, and is not observed.
+	StoragePolicyID   string
+	ObjectLockEnabled bool
+}
+
+// NewClient creates a client of the S3 API at endpoint, which is an url with
+// protocol, hostname and port. Requests are sent by httpClient, or by the
+// default HTTP client if it is nil.
+func NewClient(endpoint, region, accessKey, secretKey string, httpClient *http.Client) *Client {
+	if region == "" {
+		region = DefaultRegion
+	}
+	return &Client{
+		s3: s3.New(s3.Options{
+			BaseEndpoint: aws.String(endpoint),
+			Region:       region,
+			Credentials:  credentials.NewStaticCredentialsProvider(accessKey, secretKey, ""),
+			HTTPClient:   httpClient,
+			// Cloudian does not require wildcard DNS records with path style requests.
+			UsePathStyle: true,
+		}),
+	}
+}
+
+// CreateBucket creates a bucket owned by the user of the client.
+func (client Client) CreateBucket(ctx context.Context, bucket Bucket) error {
+	input := &s3.CreateBucketInput{
+		Bucket:                     aws.String(bucket.Name),
+		ObjectLockEnabledForBucket: aws.Bool(bucket.ObjectLockEnabled),
+	}
+	if bucket.Region != "" && bucket.Region != DefaultRegion {
+		input.CreateBucketConfiguration = &types.CreateBucketConfiguration{
+			LocationConstraint: types.BucketLocationConstraint(bucket.Region),
+		}
+	}
+
+	var opts []func(*s3.Options)
+	if bucket.StoragePolicyID != "" {
+		opts = append(opts, func(o *s3.Options) {
+			o.APIOptions = append(o.APIOptions, smithyhttp.AddHeaderValue(headerStoragePolicyID, bucket.StoragePolicyID))
+		})
+	}
+
+	if _, err := client.s3.CreateBucket(ctx, input, opts...); err != nil {
+		return fmt.Errorf("CREATE bucket failed: %w", err)
+	}
+	return nil
+}
+
+// GetBucket returns the observable configuration of a bucket, or ErrNotFound
+// if it does not exist.
+func (client Client) GetBucket(ctx context.Context, name string) (*Bucket, error) {
+	if _, err := client.s3.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(name)}); err != nil {
+		return nil, fmt.Errorf("HEAD bucket failed: %w", notFound(err))
+	}
+
+	location, err := client.s3.GetBucketLocation(ctx, &s3.GetBucketLocationInput{Bucket: aws.String(name)})
+	if err != nil {
+		return nil, fmt.Errorf("GET bucket location failed: %w", notFound(err))
+	}
+
+	bucket := &Bucket{Name: name, Region: string(location.LocationConstraint)}
+
+	lock, err := client.s3.GetObjectLockConfiguration(ctx, &s3.GetObjectLockConfigurationInput{Bucket: aws.String(name)})
+	switch {
+	case hasErrorCode(err, "ObjectLockConfigurationNotFoundError"):
+	case err != nil:
+		return nil, fmt.Errorf("GET bucket object lock configuration failed: %w", notFound(err))
+	default:
+		bucket.ObjectLockEnabled = lock.ObjectLockConfiguration != nil &&
+			lock.ObjectLockConfiguration.ObjectLockEnabled == types.ObjectLockEnabledEnabled
+	}
+
+	return bucket, nil
+}
+
+// DeleteBucket deletes an empty bucket, or returns ErrNotFound if it does not
+// exist.
+func (client Client) DeleteBucket(ctx context.Context, name string) error {
+	if _, err := client.s3.DeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: aws.String(name)}); err != nil {
+		return fmt.Errorf("DELETE bucket failed: %w", notFound(err))
+	}
+	return nil
+}
+
+// notFound wraps ErrNotFound around errors of missing buckets, and of missing
+// bucket configurations with the given error codes.
+func notFound(err error, codes ...string) error {
+	if hasErrorCode(err, append(codes, "NoSuchBucket", "NotFound")...) {
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	return err
+}
+
+func hasErrorCode(err error, codes ...string) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, code := range codes {
+		if apiErr.ErrorCode() == code {
+			return true
+		}
+	}
+	return false
+}
+
+// LifecycleRule expires objects of a bucket.
+type LifecycleRule struct {
+	ID      string
+	Enabled bool
+	// Prefix limits the rule to objects with keys starting with it.
+	Prefix string
+	// ExpirationDays expires current object versions after a number of days.
+	ExpirationDays *int32
+	// NoncurrentVersionExpirationDays deletes object versions a number of
+	// days after they become noncurrent.
+	NoncurrentVersionExpirationDays *int32
+	// AbortIncompleteMultipartUploadDays aborts multipart uploads that are
+	// not completed a number of days after they are initiated.
+	AbortIncompleteMultipartUploadDays *int32
+}
+
+// CORSRule allows cross-origin requests to a bucket.
+type CORSRule struct {
+	ID             string
+	AllowedMethods []string
+	AllowedOrigins []string
+	AllowedHeaders []string
+	ExposeHeaders  []string
+	MaxAgeSeconds  *int32
+}
+
+// Versioning states of a bucket. A bucket that has never been versioned has
+// an empty versioning state.
+const (
+	VersioningEnabled   = string(types.BucketVersioningStatusEnabled)
+	VersioningSuspended = string(types.BucketVersioningStatusSuspended)
+)
+
+// GetBucketPolicy returns the JSON policy document of a bucket, or ErrNotFound
+// if the bucket or its policy does not exist.
+func (client Client) GetBucketPolicy(ctx context.Context, bucket string) (string, error) {
+	out, err := client.s3.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{Bucket: aws.String(bucket)})
+	if err != nil {
+		return "", fmt.Errorf("GET bucket policy failed: %w", notFound(err, "NoSuchBucketPolicy"))
+	}
+	return aws.ToString(out.Policy), nil
+}
+
+// PutBucketPolicy sets the JSON policy document of a bucket.
+func (client Client) PutBucketPolicy(ctx context.Context, bucket, policy string) error {
+	if _, err := client.s3.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{Bucket: aws.String(bucket), Policy: aws.String(policy)}); err != nil {
+		return fmt.Errorf("PUT bucket policy failed: %w", notFound(err))
+	}
+	return nil
+}
+
+// DeleteBucketPolicy deletes the policy of a bucket, or returns ErrNotFound if
+// the bucket does not exist.
+func (client Client) DeleteBucketPolicy(ctx context.Context, bucket string) error {
+	if _, err := client.s3.DeleteBucketPolicy(ctx, &s3.DeleteBucketPolicyInput{Bucket: aws.String(bucket)}); err != nil {
+		return fmt.Errorf("DELETE bucket policy failed: %w", notFound(err))
+	}
+	return nil
+}
+
+// GetBucketVersioning returns the versioning state of a bucket, or ErrNotFound
+// if the bucket does not exist.
+func (client Client) GetBucketVersioning(ctx context.Context, bucket string) (string, error) {
+	out, err := client.s3.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{Bucket: aws.String(bucket)})
+	if err != nil {
+		return "", fmt.Errorf("GET bucket versioning failed: %w", notFound(err))
+	}
+	return string(out.Status), nil
+}
+
+// PutBucketVersioning sets the versioning state of a bucket.
+func (client Client) PutBucketVersioning(ctx context.Context, bucket, status string) error {
+	input := &s3.PutBucketVersioningInput{
+		Bucket:                  aws.String(bucket),
+		VersioningConfiguration: &types.VersioningConfiguration{Status: types.BucketVersioningStatus(status)},
+	}
+	if _, err := client.s3.PutBucketVersioning(ctx, input); err != nil {
+		return fmt.Errorf("PUT bucket versioning failed: %w", notFound(err))
+	}
+	return nil
+}
+
+// GetBucketLifecycle returns the lifecycle rules of a bucket, or ErrNotFound if
+// the bucket or its lifecycle configuration does not exist.
+func (client Client) GetBucketLifecycle(ctx context.Context, bucket string) ([]LifecycleRule, error) {
+	out, err := client.s3.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: aws.String(bucket)})
+	if err != nil {
+		return nil, fmt.Errorf("GET bucket lifecycle failed: %w", notFound(err, "NoSuchLifecycleConfiguration"))
+	}
+
+	rules := make([]LifecycleRule, 0, len(out.Rules))
+	for _, r := range out.Rules {
+		rule := LifecycleRule{
+			ID:      aws.ToString(r.ID),
+			Enabled: r.Status == types.ExpirationStatusEnabled,
+			Prefix:  aws.ToString(r.Prefix),
+		}
+		if r.Filter != nil && r.Filter.Prefix != nil {
+			rule.Prefix = *r.Filter.Prefix
+		}
+		if r.Expiration != nil {
+			rule.ExpirationDays = r.Expiration.Days
+		}
+		if r.NoncurrentVersionExpiration != nil {
+			rule.NoncurrentVersionExpirationDays = r.NoncurrentVersionExpiration.NoncurrentDays
+		}
+		if r.AbortIncompleteMultipartUpload != nil {
+			rule.AbortIncompleteMultipartUploadDays = r.AbortIncompleteMultipartUpload.DaysAfterInitiation
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// PutBucketLifecycle replaces the lifecycle rules of a bucket.
+func (client Client) PutBucketLifecycle(ctx context.Context, bucket string, rules []LifecycleRule) error {
+	config := &types.BucketLifecycleConfiguration{}
+	for _, r := range rules {
+		rule := types.LifecycleRule{
+			ID:     aws.String(r.ID),
+			Status: types.ExpirationStatusDisabled,
+			Filter: &types.LifecycleRuleFilter{Prefix: aws.String(r.Prefix)},
+		}
+		if r.Enabled {
+			rule.Status = types.ExpirationStatusEnabled
+		}
+		if r.ExpirationDays != nil {
+			rule.Expiration = &types.LifecycleExpiration{Days: r.ExpirationDays}
+		}
+		if r.NoncurrentVersionExpirationDays != nil {
+			rule.NoncurrentVersionExpiration = &types.NoncurrentVersionExpiration{NoncurrentDays: r.NoncurrentVersionExpirationDays}
+		}
+		if r.AbortIncompleteMultipartUploadDays != nil {
+			rule.AbortIncompleteMultipartUpload = &types.AbortIncompleteMultipartUpload{DaysAfterInitiation: r.AbortIncompleteMultipartUploadDays}
+		}
+		config.Rules = append(config.Rules, rule)
+	}
+
+	input := &s3.PutBucketLifecycleConfigurationInput{Bucket: aws.String(bucket), LifecycleConfiguration: config}
+	if _, err := client.s3.PutBucketLifecycleConfiguration(ctx, input); err != nil {
+		return fmt.Errorf("PUT bucket lifecycle failed: %w", notFound(err))
+	}
+	return nil
+}
+
+// DeleteBucketLifecycle deletes the lifecycle rules of a bucket, or returns
+// ErrNotFound if the bucket does not exist.
+func (client Client) DeleteBucketLifecycle(ctx context.Context, bucket string) error {
+	if _, err := client.s3.DeleteBucketLifecycle(ctx, &s3.DeleteBucketLifecycleInput{Bucket: aws.String(bucket)}); err != nil {
+		return fmt.Errorf("DELETE bucket lifecycle failed: %w", notFound(err))
+	}
+	return nil
+}
+
+// GetBucketCORS returns the CORS rules of a bucket, or ErrNotFound if the
+// bucket or its CORS configuration does not exist.
+func (client Client) GetBucketCORS(ctx context.Context, bucket string) ([]CORSRule, error) {
+	out, err := client.s3.GetBucketCors(ctx, &s3.GetBucketCorsInput{Bucket: aws.String(bucket)})
+	if err != nil {
+		return nil, fmt.Errorf("GET bucket CORS failed: %w", notFound(err, "NoSuchCORSConfiguration"))
+	}
+
+	rules := make([]CORSRule, 0, len(out.CORSRules))
+	for _, r := range out.CORSRules {
+		rules = append(rules, CORSRule{
+			ID:             aws.ToString(r.ID),
+			AllowedMethods: r.AllowedMethods,
+			AllowedOrigins: r.AllowedOrigins,
+			AllowedHeaders: r.AllowedHeaders,
+			ExposeHeaders:  r.ExposeHeaders,
+			MaxAgeSeconds:  r.MaxAgeSeconds,
+		})
+	}
+	return rules, nil
+}
+
+// PutBucketCORS replaces the CORS rules of a bucket.
+func (client Client) PutBucketCORS(ctx context.Context, bucket string, rules []CORSRule) error {
+	config := &types.CORSConfiguration{}
+	for _, r := range rules {
+		rule := types.CORSRule{
+			AllowedMethods: r.AllowedMethods,
+			AllowedOrigins: r.AllowedOrigins,
+			AllowedHeaders: r.AllowedHeaders,
+			ExposeHeaders:  r.ExposeHeaders,
+			MaxAgeSeconds:  r.MaxAgeSeconds,
+		}
+		if r.ID != "" {
+			rule.ID = aws.String(r.ID)
+		}
+		config.CORSRules = append(config.CORSRules, rule)
+	}
+
+	if _, err := client.s3.PutBucketCors(ctx, &s3.PutBucketCorsInput{Bucket: aws.String(bucket), CORSConfiguration: config}); err != nil {
+		return fmt.Errorf("PUT bucket CORS failed: %w", notFound(err))
+	}
+	return nil
+}
+
+// DeleteBucketCORS deletes the CORS rules of a bucket, or returns ErrNotFound
+// if the bucket does not exist.
+func (client Client) DeleteBucketCORS(ctx context.Context, bucket string) error {
+	if _, err := client.s3.DeleteBucketCors(ctx, &s3.DeleteBucketCorsInput{Bucket: aws.String(bucket)}); err != nil {
+		return fmt.Errorf("DELETE bucket CORS failed: %w", notFound(err))
+	}
+	return nil
+}
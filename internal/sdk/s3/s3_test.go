@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/statnett/provider-cloudian/internal/sdk/s3/s3test"
+)
+
+func mockS3() (*Client, *s3test.Server) {
+	server := s3test.NewServer()
+	return NewClient(server.URL, "region1", "AKID", "secret", server.Client()), server
+}
+
+func TestCreateBucket(t *testing.T) {
+	client, server := mockS3()
+	defer server.Close()
+
+	err := client.CreateBucket(context.TODO(), Bucket{
+		Name:              "foo",
+		Region:            "region1",
+		StoragePolicyID:   "policy1",
+		ObjectLockEnabled: true,
+	})
+	if err != nil {
+		t.Fatalf("Error creating bucket: %v", err)
+	}
+
+	got, ok := server.Bucket("foo")
+	if !ok {
+		t.Fatal("CreateBucket() did not create the bucket")
+	}
+	want := s3test.Bucket{Region: "region1", StoragePolicyID: "policy1", ObjectLockEnabled: true}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CreateBucket() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetBucket(t *testing.T) {
+	client, server := mockS3()
+	defer server.Close()
+
+	server.SetBucket("locked", s3test.Bucket{Region: "region1", ObjectLockEnabled: true})
+	server.SetBucket("unlocked", s3test.Bucket{Region: "region2"})
+
+	cases := map[string]struct {
+		want *Bucket
+		err  error
+	}{
+		"locked":   {want: &Bucket{Name: "locked", Region: "region1", ObjectLockEnabled: true}},
+		"unlocked": {want: &Bucket{Name: "unlocked", Region: "region2"}},
+		"missing":  {err: ErrNotFound},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := client.GetBucket(context.TODO(), name)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("GetBucket() error = %v, want %v", err, tc.err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GetBucket() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeleteBucket(t *testing.T) {
+	client, server := mockS3()
+	defer server.Close()
+
+	server.SetBucket("foo", s3test.Bucket{})
+
+	if err := client.DeleteBucket(context.TODO(), "foo"); err != nil {
+		t.Fatalf("Error deleting bucket: %v", err)
+	}
+	if _, ok := server.Bucket("foo"); ok {
+		t.Error("DeleteBucket() did not delete the bucket")
+	}
+	if err := client.DeleteBucket(context.TODO(), "foo"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected error to be ErrNotFound, got %v", err)
+	}
+}
+
+func TestBucketPolicy(t *testing.T) {
+	client, server := mockS3()
+	defer server.Close()
+
+	server.SetBucket("foo", s3test.Bucket{})
+	ctx := context.TODO()
+
+	if _, err := client.GetBucketPolicy(ctx, "foo"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected error to be ErrNotFound, got %v", err)
+	}
+
+	policy := `{"Version":"2012-10-17","Statement":[]}`
+	if err := client.PutBucketPolicy(ctx, "foo", policy); err != nil {
+		t.Fatalf("Error putting bucket policy: %v", err)
+	}
+	got, err := client.GetBucketPolicy(ctx, "foo")
+	if err != nil {
+		t.Fatalf("Error getting bucket policy: %v", err)
+	}
+	if diff := cmp.Diff(policy, got); diff != "" {
+		t.Errorf("GetBucketPolicy() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := client.DeleteBucketPolicy(ctx, "foo"); err != nil {
+		t.Fatalf("Error deleting bucket policy: %v", err)
+	}
+	if _, err := client.GetBucketPolicy(ctx, "foo"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected error to be ErrNotFound, got %v", err)
+	}
+}
+
+func TestBucketVersioning(t *testing.T) {
+	client, server := mockS3()
+	defer server.Close()
+
+	server.SetBucket("foo", s3test.Bucket{})
+	ctx := context.TODO()
+
+	if err := client.PutBucketVersioning(ctx, "foo", VersioningEnabled); err != nil {
+		t.Fatalf("Error putting bucket versioning: %v", err)
+	}
+	got, err := client.GetBucketVersioning(ctx, "foo")
+	if err != nil {
+		t.Fatalf("Error getting bucket versioning: %v", err)
+	}
+	if got != VersioningEnabled {
+		t.Errorf("GetBucketVersioning() = %q, want %q", got, VersioningEnabled)
+	}
+	if _, err := client.GetBucketVersioning(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected error to be ErrNotFound, got %v", err)
+	}
+}
+
+func TestBucketLifecycle(t *testing.T) {
+	client, server := mockS3()
+	defer server.Close()
+
+	server.SetBucket("foo", s3test.Bucket{})
+	ctx := context.TODO()
+
+	days := func(d int32) *int32 { return &d }
+	rules := []LifecycleRule{
+		{ID: "expire-logs", Enabled: true, Prefix: "logs/", ExpirationDays: days(30)},
+		{ID: "cleanup", NoncurrentVersionExpirationDays: days(7), AbortIncompleteMultipartUploadDays: days(1)},
+	}
+	if err := client.PutBucketLifecycle(ctx, "foo", rules); err != nil {
+		t.Fatalf("Error putting bucket lifecycle: %v", err)
+	}
+	got, err := client.GetBucketLifecycle(ctx, "foo")
+	if err != nil {
+		t.Fatalf("Error getting bucket lifecycle: %v", err)
+	}
+	if diff := cmp.Diff(rules, got); diff != "" {
+		t.Errorf("GetBucketLifecycle() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := client.DeleteBucketLifecycle(ctx, "foo"); err != nil {
+		t.Fatalf("Error deleting bucket lifecycle: %v", err)
+	}
+	if _, err := client.GetBucketLifecycle(ctx, "foo"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected error to be ErrNotFound, got %v", err)
+	}
+}
+
+func TestBucketCORS(t *testing.T) {
+	client, server := mockS3()
+	defer server.Close()
+
+	server.SetBucket("foo", s3test.Bucket{})
+	ctx := context.TODO()
+
+	maxAge := int32(3600)
+	rules := []CORSRule{
+		{ID: "web", AllowedMethods: []string{"GET", "HEAD"}, AllowedOrigins: []string{"https://example.com"}, AllowedHeaders: []string{"*"}, MaxAgeSeconds: &maxAge},
+	}
+	if err := client.PutBucketCORS(ctx, "foo", rules); err != nil {
+		t.Fatalf("Error putting bucket CORS: %v", err)
+	}
+	got, err := client.GetBucketCORS(ctx, "foo")
+	if err != nil {
+		t.Fatalf("Error getting bucket CORS: %v", err)
+	}
+	if diff := cmp.Diff(rules, got); diff != "" {
+		t.Errorf("GetBucketCORS() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := client.DeleteBucketCORS(ctx, "foo"); err != nil {
+		t.Fatalf("Error deleting bucket CORS: %v", err)
+	}
+	if _, err := client.GetBucketCORS(ctx, "foo"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected error to be ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package s3test provides an in-process stand-in for the S3 API of Cloudian,
+// for use in tests. It serves path style bucket requests, and keeps buckets
+// in memory. Requests are not authenticated.
+package s3test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// Bucket is the state of a bucket in a Server. Lifecycle and CORS
+// configurations are kept as the XML documents they were put as.
+type Bucket struct {
+	Region            string
+	StoragePolicyID   string
+	ObjectLockEnabled bool
+	Policy            string
+	Versioning        string
+	Lifecycle         string
+	CORS              string
+}
+
+// A Server is an in-memory S3 API.
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	buckets map[string]*Bucket
+}
+
+// NewServer starts a Server. Callers must call Close when done.
+func NewServer() *Server {
+	s := &Server{buckets: map[string]*Bucket{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Bucket returns a copy of a bucket, and whether it exists.
+func (s *Server) Bucket(name string) (Bucket, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[name]
+	if !ok {
+		return Bucket{}, false
+	}
+	return *b, true
+}
+
+// SetBucket creates or replaces a bucket.
+func (s *Server) SetBucket(name string, b Bucket) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.buckets[name] = &b
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if name == "" || key != "" {
+		writeError(w, http.StatusNotImplemented, "NotImplemented", "only bucket requests are supported")
+		return
+	}
+
+	if r.Method == http.MethodPut && len(r.URL.Query()) == 0 {
+		s.createBucket(w, r, name)
+		return
+	}
+
+	bucket, ok := s.buckets[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	query := r.URL.Query()
+	switch {
+	case r.Method == http.MethodHead:
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodDelete && len(query) == 0:
+		delete(s.buckets, name)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && query.Has("location"):
+		writeXML(w, struct {
+			XMLName xml.Name `xml:"LocationConstraint"`
+			Value   string   `xml:",chardata"`
+		}{Value: bucket.Region})
+	case query.Has("policy"):
+		s.subresource(w, r, &bucket.Policy, "NoSuchBucketPolicy", "The bucket policy does not exist")
+	case query.Has("lifecycle"):
+		s.subresource(w, r, &bucket.Lifecycle, "NoSuchLifecycleConfiguration", "The lifecycle configuration does not exist")
+	case query.Has("cors"):
+		s.subresource(w, r, &bucket.CORS, "NoSuchCORSConfiguration", "The CORS configuration does not exist")
+	case query.Has("versioning"):
+		s.versioning(w, r, bucket)
+	case r.Method == http.MethodGet && query.Has("object-lock"):
+		if !bucket.ObjectLockEnabled {
+			writeError(w, http.StatusNotFound, "ObjectLockConfigurationNotFoundError", "Object Lock configuration does not exist for this bucket")
+			return
+		}
+		writeXML(w, struct {
+			XMLName           xml.Name `xml:"ObjectLockConfiguration"`
+			ObjectLockEnabled string   `xml:"ObjectLockEnabled"`
+		}{ObjectLockEnabled: "Enabled"})
+	default:
+		writeError(w, http.StatusNotImplemented, "NotImplemented", fmt.Sprintf("%s %s is not supported", r.Method, r.URL))
+	}
+}
+
+func (s *Server) createBucket(w http.ResponseWriter, r *http.Request, name string) {
+	if _, ok := s.buckets[name]; ok {
+		writeError(w, http.StatusConflict, "BucketAlreadyOwnedByYou", "Your previous request to create the named bucket succeeded and you already own it")
+		return
+	}
+
+	var config struct {
+		LocationConstraint string `xml:"LocationConstraint"`
+	}
+	if r.ContentLength != 0 {
+		if err := xml.NewDecoder(r.Body).Decode(&config); err != nil {
+			writeError(w, http.StatusBadRequest, "MalformedXML", err.Error())
+			return
+		}
+	}
+
+	s.buckets[name] = &Bucket{
+		Region:            config.LocationConstraint,
+		StoragePolicyID:   r.Header.Get("x-gmt-policyid"),
+		ObjectLockEnabled: r.Header.Get("x-amz-bucket-object-lock-enabled") == "true",
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// subresource gets, puts or deletes a configuration of a bucket that is
+// stored as the document it was put as.
+func (s *Server) subresource(w http.ResponseWriter, r *http.Request, doc *string, code, message string) {
+	switch r.Method {
+	case http.MethodGet:
+		if *doc == "" {
+			writeError(w, http.StatusNotFound, code, message)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, *doc)
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+			return
+		}
+		*doc = string(body)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		*doc = ""
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method+" is not allowed")
+	}
+}
+
+type versioningConfiguration struct {
+	XMLName xml.Name `xml:"VersioningConfiguration"`
+	Status  string   `xml:"Status,omitempty"`
+}
+
+func (s *Server) versioning(w http.ResponseWriter, r *http.Request, bucket *Bucket) {
+	switch r.Method {
+	case http.MethodGet:
+		writeXML(w, versioningConfiguration{Status: bucket.Versioning})
+	case http.MethodPut:
+		var config versioningConfiguration
+		if err := xml.NewDecoder(r.Body).Decode(&config); err != nil {
+			writeError(w, http.StatusBadRequest, "MalformedXML", err.Error())
+			return
+		}
+		bucket.Versioning = config.Status
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method+" is not allowed")
+	}
+}
+
+func writeXML(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+	_ = xml.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_ = xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string   `xml:"Code"`
+		Message string   `xml:"Message"`
+	}{Code: code, Message: message})
+}